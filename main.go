@@ -2,12 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 
 	"github.com/keybrl/hksr-compass/pkg/commands"
+	"github.com/keybrl/hksr-compass/pkg/compass"
 )
 
 var (
@@ -22,10 +24,23 @@ func main() {
 	commands.Cmd.Version = version
 	// 执行命令
 	if err := commands.Cmd.ExecuteContext(ctx); err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		cancel()
+		os.Exit(exitCode(err))
 	}
 }
 
+// exitCode 根据错误类型返回退出码
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, compass.ErrUnsolvable):
+		return 2
+	case errors.Is(err, compass.ErrParseFormat), errors.Is(err, compass.ErrInvalidCompass):
+		return 3
+	}
+	return 1
+}
+
 // notifyContext 将信号绑定到上下文
 func notifyContext(parent context.Context, signals ...os.Signal) (context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(parent)
@@ -0,0 +1,71 @@
+package clipboard
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// ErrUnavailable 当前环境没有可用的剪贴板，比如没有图形界面的服务器
+var ErrUnavailable = errors.New("clipboard is unavailable")
+
+// Clipboard 剪贴板
+type Clipboard interface {
+	// ReadText 读取剪贴板中的文本
+	ReadText(ctx context.Context) (string, error)
+}
+
+// NewSystemClipboard 创建一个读取系统剪贴板的 Clipboard
+// 通过调用系统中读取剪贴板的命令实现，没有可用的命令时返回 ErrUnavailable
+func NewSystemClipboard() (Clipboard, error) {
+	for _, args := range pasteCommands() {
+		if _, err := exec.LookPath(args[0]); err == nil {
+			return &systemClipboard{args: args}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no command for reading clipboard found", ErrUnavailable)
+}
+
+// pasteCommands 返回当前平台可能可用的读取剪贴板的命令，按优先级排序
+func pasteCommands() [][]string {
+	switch runtime.GOOS {
+	case "darwin":
+		return [][]string{{"pbpaste"}}
+	case "windows":
+		return [][]string{{"powershell.exe", "-NoProfile", "-Command", "Get-Clipboard"}}
+	}
+	var cmds [][]string
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		cmds = append(cmds, []string{"wl-paste", "--no-newline"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		cmds = append(cmds,
+			[]string{"xclip", "-selection", "clipboard", "-o"},
+			[]string{"xsel", "--clipboard", "--output"},
+		)
+	}
+	return cmds
+}
+
+// systemClipboard 通过调用命令读取的系统剪贴板
+type systemClipboard struct {
+	args []string
+}
+
+var _ Clipboard = &systemClipboard{}
+
+// ReadText 读取剪贴板中的文本
+func (c *systemClipboard) ReadText(ctx context.Context) (string, error) {
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, c.args[0], c.args[1:]...)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("run %s error: %w (stderr: %s)", c.args[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return string(out), nil
+}
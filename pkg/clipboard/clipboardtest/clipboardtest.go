@@ -0,0 +1,28 @@
+// Package clipboardtest 提供测试读取剪贴板的代码时使用的剪贴板
+package clipboardtest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/keybrl/hksr-compass/pkg/clipboard"
+)
+
+// Fake 依次返回预设内容的剪贴板，内容用完后调用 Cancel （通常是取消轮询剪贴板的上下文）并返回错误
+type Fake struct {
+	Texts  []string
+	Cancel context.CancelFunc
+}
+
+var _ clipboard.Clipboard = &Fake{}
+
+// ReadText 返回下一个预设内容
+func (c *Fake) ReadText(_ context.Context) (string, error) {
+	if len(c.Texts) == 0 {
+		c.Cancel()
+		return "", errors.New("no more texts")
+	}
+	text := c.Texts[0]
+	c.Texts = c.Texts[1:]
+	return text, nil
+}
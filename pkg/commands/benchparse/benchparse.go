@@ -0,0 +1,122 @@
+package benchparse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/commands/source"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagNotches bool
+)
+
+// result 解析的统计结果
+type result struct {
+	// 解析的行数，包括解析失败的行
+	Lines  int `json:"lines"`
+	Errors int `json:"errors"`
+	// 解析的总耗时，不包括读取文件的耗时
+	Elapsed time.Duration `json:"elapsed_ns"`
+	// 每秒解析的行数
+	LinesPerSecond float64 `json:"lines_per_second"`
+}
+
+// Cmd bench-parse 命令
+var Cmd = &cobra.Command{
+	Use:   "bench-parse FILE",
+	Short: "Parse every compass expression in a file and report the parse rate, without solving.",
+	Long: `Parse every compass expression in a file and report the parse rate, without solving.
+
+Each line of the file is a compass expression. Lines in the "verify" format
+("COMPASS_EXPRESSION => EXPECTED_SOLUTION") are also accepted, only the compass
+expression is parsed. Blank lines and lines starting with "#" are ignored.
+
+Only the time spent parsing is measured, reading the file is not included.
+Lines failing to parse are counted as errors and logged with -v.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		f, err := os.Open(args[0])
+		if err != nil {
+			logger.Error(err, "open file error")
+			return fmt.Errorf("open file error: %w", err)
+		}
+		defer f.Close()
+
+		parse := compass.ParseCompass
+		if flagNotches {
+			parse = compass.ParseCompassNotches
+		}
+		ret, err := benchParse(cmd.Context(), logger, f, func(expr string) (compass.Compass, error) {
+			return parse(options.ExpandAliases(expr))
+		})
+		if err != nil {
+			logger.Error(err, "read file error")
+			return fmt.Errorf("read file error: %w", err)
+		}
+
+		// 输出
+		if options.Format() == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(ret)
+		}
+		fmt.Printf("Lines:   %d (%d errors)\n", ret.Lines, ret.Errors)
+		fmt.Printf("Elapsed: %s\n", ret.Elapsed)
+		fmt.Printf("Rate:    %.0f lines/s\n", ret.LinesPerSecond)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().BoolVar(&flagNotches, "notches", false, "speeds in the compass expressions are written as direction and notches, e.g. \"0cw1,4ccw4,0cw2/oi,om,mi\"")
+}
+
+// benchParse 逐行读取 r 并以 parse 解析其中的罗盘表达式，统计解析的行数、错误数及耗时
+func benchParse(ctx context.Context, logger logr.Logger, r io.Reader, parse func(string) (compass.Compass, error)) (result, error) {
+	var ret result
+	src := source.Lines(r, func(line string) (compass.Compass, error) {
+		// verify 格式的行只解析罗盘表达式
+		if i := strings.Index(line, "=>"); i >= 0 {
+			line = line[:i]
+		}
+		start := time.Now()
+		c, err := parse(line)
+		ret.Elapsed += time.Since(start)
+		ret.Lines++
+		return c, err
+	})
+	for {
+		if err := ctx.Err(); err != nil {
+			return ret, err
+		}
+		_, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, source.ErrRead) {
+			return ret, err
+		}
+		if err != nil {
+			ret.Errors++
+			logger.V(1).Info(fmt.Sprintf("parse error: %s", err))
+		}
+	}
+	if ret.Elapsed > 0 {
+		ret.LinesPerSecond = float64(ret.Lines) / ret.Elapsed.Seconds()
+	}
+	return ret, nil
+}
@@ -0,0 +1,40 @@
+package benchparse
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestBenchParse 测试 benchParse
+func TestBenchParse(t *testing.T) {
+	input := strings.Join([]string{
+		"# comment",
+		"0+1,4-4,0+2/oi,om,mi",
+		"",
+		"3+1,0-2,0+2/o => o3",
+		"foo",
+		"0+1,4-4/oi",
+	}, "\n")
+	ret, err := benchParse(context.Background(), logr.Discard(), strings.NewReader(input), compass.ParseCompass)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ret.Lines != 4 || ret.Errors != 2 {
+		t.Errorf("unexpected result: %d lines, %d errors (expected: 4 lines, 2 errors)", ret.Lines, ret.Errors)
+	}
+	if ret.Elapsed <= 0 || ret.LinesPerSecond <= 0 {
+		t.Errorf("unexpected elapsed: %s, %f lines/s (expected positive)", ret.Elapsed, ret.LinesPerSecond)
+	}
+
+	// 取消时返回错误
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := benchParse(ctx, logr.Discard(), strings.NewReader(input), compass.ParseCompass); err == nil {
+		t.Errorf("unexpected result after cancel: no error (expected an error)")
+	}
+}
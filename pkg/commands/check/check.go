@@ -0,0 +1,127 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// offset 一个不在目标位置的圈
+type offset struct {
+	Ring     string `json:"ring"`
+	Location int    `json:"location"`
+	Distance int    `json:"distance"`
+}
+
+// result 校验结果
+type result struct {
+	Compass  string `json:"compass"`
+	Solution string `json:"solution"`
+	Moves    int    `json:"moves"`
+	Solved   bool   `json:"solved"`
+	// 按解法转动后的罗盘
+	Result    string   `json:"result"`
+	OffTarget []offset `json:"off_target,omitempty"`
+}
+
+// Cmd check 命令
+var Cmd = &cobra.Command{
+	Use:   "check COMPASS_EXPRESSION SOLUTION",
+	Short: "Check whether a proposed solution solves a Navigation Compass.",
+	Long: `Check whether a proposed solution solves a Navigation Compass.
+
+SOLUTION is a solution like "mi2,oi4,om2", the count of a step may be omitted to
+rotate it once, so the clicks in the game can be written directly, e.g. "o,om,i".
+If the solution does not solve the compass, the resulting state is printed with
+how far each ring is off the target, and the command exits with a non-zero status.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 解析输入罗盘及解法
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+		solution, err := parseClicks(options.ExpandAliases(args[1]))
+		if err != nil {
+			logger.Error(err, "parse solution error")
+			return fmt.Errorf("parse solution error: %w", err)
+		}
+
+		ret, err := check(input, solution)
+		if err != nil {
+			logger.Error(err, "check solution error")
+			return fmt.Errorf("check solution error: %w", err)
+		}
+
+		// 输出
+		if options.Format() == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(ret); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("Compass:  %s\n", ret.Compass)
+			fmt.Printf("Solution: %s (%d moves)\n", ret.Solution, ret.Moves)
+			if ret.Solved {
+				fmt.Printf("Result:   solved\n")
+			} else {
+				fmt.Printf("Result:   not solved, ends at %s\n", ret.Result)
+				for _, o := range ret.OffTarget {
+					fmt.Printf("  %s ring is at location %d, %d off the target\n", o.Ring, o.Location, o.Distance)
+				}
+			}
+		}
+		if !ret.Solved {
+			return fmt.Errorf("solution does not solve the compass")
+		}
+		return nil
+	},
+}
+
+// parseClicks 解析解法，省略了转动次数的步骤转动一次
+func parseClicks(expr string) (compass.Steps, error) {
+	parts := strings.Split(expr, ",")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part != "" && !strings.ContainsAny(part[len(part)-1:], "0123456789") {
+			parts[i] = part + "1"
+		}
+	}
+	return compass.ParseSteps(strings.Join(parts, ","))
+}
+
+// check 按解法转动罗盘，返回转动后是否解开及各圈离目标位置的距离
+func check(input compass.Compass, solution compass.Steps) (result, error) {
+	if _, err := compass.CheckSolution(input, solution); err != nil {
+		return result{}, err
+	}
+	final := input.Clone()
+	if err := final.ApplySteps(solution); err != nil {
+		return result{}, err
+	}
+	ret := result{
+		Compass:  input.String(),
+		Solution: solution.OrderedString(),
+		Moves:    solution.TotalCount(),
+		Solved:   final.IsSolved(),
+		Result:   final.String(),
+	}
+	for _, o := range final.OffTargetRings() {
+		ret.OffTarget = append(ret.OffTarget, offset{
+			Ring:     strings.ToLower(o.Ring.Name()),
+			Location: o.Location,
+			Distance: o.Distance,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,61 @@
+package check
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestParseClicks 测试 parseClicks
+func TestParseClicks(t *testing.T) {
+	ret, err := parseClicks("o, om2,(o+mi)")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := compass.Steps{
+		{RingGroup: compass.OuterRingGroup, Count: 1},
+		{RingGroup: compass.OuterMiddleRingGroup, Count: 2},
+		{Composite: []compass.RingGroup{compass.OuterRingGroup, compass.MiddleInnerRingGroup}, Count: 1},
+	}
+	if !reflect.DeepEqual(ret, expected) {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+}
+
+// TestCheck 测试 check
+func TestCheck(t *testing.T) {
+	input, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+
+	ret, err := check(input, compass.Steps{
+		{RingGroup: compass.MiddleInnerRingGroup, Count: 2},
+		{RingGroup: compass.OuterInnerRingGroup, Count: 4},
+		{RingGroup: compass.OuterMiddleRingGroup, Count: 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ret.Solved || ret.OffTarget != nil {
+		t.Errorf("unexpected result: %+v (expected to be solved)", ret)
+	}
+
+	ret, err = check(input, compass.Steps{{RingGroup: compass.OuterInnerRingGroup, Count: 3}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []offset{
+		{Ring: "outer", Location: 3, Distance: 3},
+		{Ring: "middle", Location: 4, Distance: 2},
+	}
+	if ret.Solved || ret.Result != "3+1,4-4,0+2/mi,oi,om" || !reflect.DeepEqual(ret.OffTarget, expected) {
+		t.Errorf("unexpected result: %+v (expected: ends at 3+1,4-4,0+2/mi,oi,om, off target %+v)", ret, expected)
+	}
+
+	// 不支持的圈分组
+	if _, err := check(input, compass.Steps{{RingGroup: compass.OuterRingGroup, Count: 1}}); err == nil {
+		t.Errorf("expected error with unsupported ring group")
+	}
+}
@@ -0,0 +1,112 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagCost map[string]int
+)
+
+// objective 一个优化目标
+type objective struct {
+	name      string
+	newSolver func(compass.SolverOptions) (compass.Solver, error)
+}
+
+// objectives 参与比较的优化目标
+var objectives = []objective{
+	{name: "length", newSolver: compass.NewDefaultSolver},
+	{name: "dials", newSolver: compass.NewDialsSolver},
+	{name: "balanced", newSolver: compass.NewBalancedSolver},
+	{name: "cost", newSolver: compass.NewMinCostSolver},
+}
+
+// result 一个优化目标的求解结果
+type result struct {
+	Objective string `json:"objective"`
+	Solution  string `json:"solution"`
+	Moves     int    `json:"moves"`
+	Dials     int    `json:"dials"`
+	MaxCount  int    `json:"max_count"`
+	Cost      int    `json:"cost"`
+}
+
+// Cmd compare 命令
+var Cmd = &cobra.Command{
+	Use:   "compare COMPASS_EXPRESSION",
+	Short: "Solve a Navigation Compass under every optimization objective and compare the solutions.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 解析圈分组代价
+		groupCost := make(map[compass.RingGroup]int, len(flagCost))
+		for k, v := range flagCost {
+			rg, err := compass.ParseRingGroup(options.ExpandAliases(k))
+			if err != nil {
+				logger.Error(err, "parse ring group costs error")
+				return fmt.Errorf("parse ring group costs error: %w", err)
+			}
+			groupCost[rg] = v
+		}
+		// 解析输入罗盘
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+
+		// 逐个优化目标求解
+		results := make([]result, 0, len(objectives))
+		for _, o := range objectives {
+			opts := compass.SolverOptions{Logger: logger}
+			if o.name == "cost" {
+				opts.GroupCost = groupCost
+			}
+			solver, err := o.newSolver(opts)
+			if err != nil {
+				logger.Error(err, "new solver for navigation compass error")
+				return fmt.Errorf("new %s solver for navigation compass error: %w", o.name, err)
+			}
+			solution, err := solver.Solve(cmd.Context(), input)
+			if err != nil {
+				logger.Error(err, "solve navigation compass error")
+				return fmt.Errorf("solve navigation compass with objective %s error: %w", o.name, err)
+			}
+			results = append(results, result{
+				Objective: o.name,
+				Solution:  solution.String(),
+				Moves:     solution.TotalCount(),
+				Dials:     solution.Dials(),
+				MaxCount:  solution.MaxCount(),
+				Cost:      solution.TotalCost(groupCost),
+			})
+		}
+
+		// 输出
+		if options.Format() == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(results)
+		}
+		fmt.Printf("Compass:  %s\n", input.String())
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "objective\tsolution\tmoves\tdials\tmax\tcost\t\n")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t\n", r.Objective, r.Solution, r.Moves, r.Dials, r.MaxCount, r.Cost)
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	Cmd.Flags().StringToIntVar(&flagCost, "cost", nil, "cost of ring groups for the cost objective, e.g. \"om=2,i=3\" (others cost 1)")
+}
@@ -0,0 +1,78 @@
+package daily
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagDate   string
+	flagGroups string
+	flagSolve  bool
+)
+
+// Cmd daily 命令
+var Cmd = &cobra.Command{
+	Use:   "daily",
+	Short: "Show the daily Navigation Compass, which is the same for everyone on a given date.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 解析参数
+		date := time.Now()
+		if flagDate != "" {
+			d, err := time.Parse("2006-01-02", flagDate)
+			if err != nil {
+				logger.Error(err, "parse date error")
+				return fmt.Errorf("parse date error: %w", err)
+			}
+			date = d
+		}
+		var rgs []compass.RingGroup
+		if flagGroups != "" {
+			var err error
+			rgs, err = compass.ParseRingGroups(options.ExpandAliases(flagGroups))
+			if err != nil {
+				logger.Error(err, "parse ring groups error")
+				return fmt.Errorf("parse ring groups error: %w", err)
+			}
+		}
+
+		daily := compass.DailyCompass(date, rgs...)
+		fmt.Printf("Date:     %s\n", date.Format("2006-01-02"))
+		fmt.Printf("Compass:  %s\n", daily.String())
+		if depth, err := daily.ScrambleDepth(); err == nil {
+			fmt.Printf("Depth:    %d (minimal moves to scramble from the solved state)\n", depth)
+		}
+		if !flagSolve {
+			return nil
+		}
+
+		// 求解罗盘
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+		solution, err := solver.Solve(cmd.Context(), *daily)
+		if err != nil {
+			logger.Error(err, "solve navigation compass error")
+			return fmt.Errorf("solve navigation compass error: %w", err)
+		}
+		fmt.Printf("Solution: %s\n", solution.String())
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDate, "date", "", "date of the daily compass in the format \"2006-01-02\" (default today)")
+	Cmd.Flags().StringVar(&flagGroups, "groups", "", "ring groups of the compass, e.g. \"oi,om,mi\" (default chosen randomly)")
+	Cmd.Flags().BoolVar(&flagSolve, "solve", false, "also show the solution")
+}
@@ -0,0 +1,159 @@
+package enumcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+const (
+	// version 缓存格式的版本，格式或求解结果可能变化时递增，版本不同的缓存视为失效
+	version = 2
+	// 用户配置目录下保存缓存的目录
+	defaultDirName = "hksr-compass/cache"
+)
+
+// entry 一种圈分组和旋转速度下 compass.Enumerate 的结果的缓存
+type entry struct {
+	Version int      `json:"version"`
+	Key     string   `json:"key"`
+	Records []record `json:"records"`
+}
+
+// record 一个可解的罗盘及其解法
+// 罗盘以 JSON 表示保存（参见 compass.Compass.MarshalJSON ），不依赖字符串表示能否被解析
+type record struct {
+	Compass  compass.Compass `json:"compass"`
+	Solution string          `json:"solution"`
+}
+
+// Cache 以文件保存的 compass.Enumerate 的结果，以圈分组和旋转速度为键
+// 只应缓存使用默认求解器枚举的结果
+type Cache struct {
+	dir string
+}
+
+// New 创建一个保存在指定目录中的 Cache ，目录不存在时在保存时自动创建
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Default 返回保存在用户配置目录下（比如 Linux 下的 ~/.config/hksr-compass/cache ）的 Cache
+func Default() (*Cache, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("get user config dir error: %w", err)
+	}
+	return New(filepath.Join(dir, defaultDirName)), nil
+}
+
+// Enumerate 同 compass.Enumerate ，有缓存时直接返回缓存的结果，否则枚举后写入缓存，返回是否命中缓存
+// 缓存不存在、版本不同或无法解析时重新枚举，写入缓存失败时返回枚举的结果及写入缓存的错误
+func (c *Cache) Enumerate(ctx context.Context, solver compass.Solver, rgs []compass.RingGroup, speeds [3]int) ([]compass.EnumeratedCompass, bool, error) {
+	k := key(rgs, speeds)
+	path := filepath.Join(c.dir, "enumerate-"+k+".json")
+	if ret, err := load(path, k); err == nil {
+		return ret, true, nil
+	}
+
+	ret, err := compass.Enumerate(ctx, solver, rgs, speeds)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := save(path, k, ret); err != nil {
+		return ret, false, fmt.Errorf("write cache error: %w", err)
+	}
+	return ret, false, nil
+}
+
+// Enumerate 同 compass.Enumerate ， useCache 为 true 时使用用户配置目录下的缓存
+// 缓存不可用或写入缓存失败时只记录日志，不影响枚举的结果
+func Enumerate(ctx context.Context, logger logr.Logger, solver compass.Solver, rgs []compass.RingGroup, speeds [3]int, useCache bool) ([]compass.EnumeratedCompass, error) {
+	if !useCache {
+		return compass.Enumerate(ctx, solver, rgs, speeds)
+	}
+	c, err := Default()
+	if err != nil {
+		logger.V(1).Info(fmt.Sprintf("enumerate cache is unavailable: %s", err))
+		return compass.Enumerate(ctx, solver, rgs, speeds)
+	}
+	ret, hit, err := c.Enumerate(ctx, solver, rgs, speeds)
+	if ret == nil && err != nil {
+		return nil, err
+	}
+	if err != nil {
+		logger.V(1).Info(fmt.Sprintf("enumerate cache is not written: %s", err))
+	}
+	logger.V(1).Info(fmt.Sprintf("enumerate cache hit: %t", hit))
+	return ret, nil
+}
+
+// key 返回圈分组和旋转速度的缓存键，圈分组按标准化顺序排列，与给出的顺序无关，比如 "mi.oi.om_1_-4_2"
+func key(rgs []compass.RingGroup, speeds [3]int) string {
+	std := (&compass.Compass{RingGroups: rgs}).Standardize()
+	names := make([]string, len(std.RingGroups))
+	for i, rg := range std.RingGroups {
+		names[i] = rg.ShortName()
+	}
+	return strings.Join(names, ".") + "_" + strconv.Itoa(speeds[0]) + "_" + strconv.Itoa(speeds[1]) + "_" + strconv.Itoa(speeds[2])
+}
+
+// load 读取缓存
+func load(path, k string) ([]compass.EnumeratedCompass, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if e.Version != version || e.Key != k {
+		return nil, errors.New("stale cache")
+	}
+	ret := make([]compass.EnumeratedCompass, len(e.Records))
+	for i, r := range e.Records {
+		ret[i].Compass = r.Compass
+		if ret[i].Solution, err = compass.ParseSteps(r.Solution); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// save 写入缓存，先写入临时文件再重命名，避免并发运行时读到不完整的缓存
+func save(path, k string, enumerated []compass.EnumeratedCompass) error {
+	e := entry{Version: version, Key: k, Records: make([]record, len(enumerated))}
+	for i, ec := range enumerated {
+		e.Records[i] = record{Compass: ec.Compass, Solution: ec.Solution.String()}
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
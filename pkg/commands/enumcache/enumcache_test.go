@@ -0,0 +1,94 @@
+package enumcache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestCacheEnumerate 测试 Cache.Enumerate ，命中缓存时的结果与直接枚举相同
+func TestCacheEnumerate(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new solver error: %s", err)
+	}
+	dir := t.TempDir()
+	c := New(dir)
+	ctx := context.Background()
+	speeds := [3]int{1, -4, 2}
+	rgs := []compass.RingGroup{compass.OuterInnerRingGroup, compass.OuterMiddleRingGroup, compass.MiddleInnerRingGroup}
+
+	expected, err := compass.Enumerate(ctx, solver, rgs, speeds)
+	if err != nil {
+		t.Fatalf("enumerate error: %s", err)
+	}
+	toStrings := func(enumerated []compass.EnumeratedCompass) []string {
+		var ret []string
+		for _, e := range enumerated {
+			ret = append(ret, e.Compass.String()+" "+e.Solution.String())
+		}
+		return ret
+	}
+
+	ret, hit, err := c.Enumerate(ctx, solver, rgs, speeds)
+	if err != nil || hit || !reflect.DeepEqual(toStrings(ret), toStrings(expected)) {
+		t.Errorf("unexpected result of the first run: %d compasses, %t, %v (expected: %d compasses, false, nil)", len(ret), hit, err, len(expected))
+	}
+	// 圈分组的顺序不影响缓存键
+	reversed := []compass.RingGroup{rgs[2], rgs[1], rgs[0]}
+	ret, hit, err = c.Enumerate(ctx, solver, reversed, speeds)
+	if err != nil || !hit || !reflect.DeepEqual(toStrings(ret), toStrings(expected)) {
+		t.Errorf("unexpected result of the second run: %d compasses, %t, %v (expected: %d compasses, true, nil)", len(ret), hit, err, len(expected))
+	}
+
+	// 版本不同的缓存视为失效
+	path := filepath.Join(dir, "enumerate-"+key(rgs, speeds)+".json")
+	if err := os.WriteFile(path, []byte(`{"version":0,"key":"`+key(rgs, speeds)+`","records":[]}`), 0o644); err != nil {
+		t.Fatalf("write stale cache error: %s", err)
+	}
+	ret, hit, err = c.Enumerate(ctx, solver, rgs, speeds)
+	if err != nil || hit || len(ret) != len(expected) {
+		t.Errorf("unexpected result with stale cache: %d compasses, %t, %v (expected: %d compasses, false, nil)", len(ret), hit, err, len(expected))
+	}
+}
+
+// TestCacheEnumerateZeroSpeed 测试 Cache.Enumerate ，有不会转动的圈时同样能命中缓存
+func TestCacheEnumerateZeroSpeed(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new solver error: %s", err)
+	}
+	c := New(t.TempDir())
+	ctx := context.Background()
+	speeds := [3]int{1, 1, 0}
+	rgs := []compass.RingGroup{compass.OuterRingGroup, compass.MiddleRingGroup}
+
+	expected, _, err := c.Enumerate(ctx, solver, rgs, speeds)
+	if err != nil || len(expected) == 0 {
+		t.Fatalf("unexpected result of the first run: %d compasses, %v", len(expected), err)
+	}
+	ret, hit, err := c.Enumerate(ctx, solver, rgs, speeds)
+	if err != nil || !hit || len(ret) != len(expected) {
+		t.Errorf("unexpected result of the second run: %d compasses, %t, %v (expected: %d compasses, true, nil)", len(ret), hit, err, len(expected))
+		return
+	}
+	for i := range ret {
+		if !ret[i].Compass.Equal(&expected[i].Compass) || ret[i].Solution.String() != expected[i].Solution.String() {
+			t.Errorf("unexpected result at index %d: %s %s (expected: %s %s)", i, ret[i].Compass.String(), ret[i].Solution.String(), expected[i].Compass.String(), expected[i].Solution.String())
+		}
+	}
+}
+
+// TestKey 测试 key
+func TestKey(t *testing.T) {
+	ret := key([]compass.RingGroup{compass.OuterMiddleRingGroup, compass.MiddleInnerRingGroup, compass.OuterInnerRingGroup}, [3]int{1, -4, 2})
+	if ret != "mi.oi.om_1_-4_2" {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, "mi.oi.om_1_-4_2")
+	}
+}
@@ -0,0 +1,108 @@
+package enumerate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/enumcache"
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagGroups  string
+	flagSpeeds  []int
+	flagOutput  string
+	flagNoCache bool
+)
+
+// record 一个可解的罗盘及其最少转动次数的解法
+type record struct {
+	Compass  string `json:"compass"`
+	Solution string `json:"solution"`
+	Moves    int    `json:"moves"`
+}
+
+// Cmd enumerate 命令
+var Cmd = &cobra.Command{
+	Use:   "enumerate",
+	Short: "Enumerate all solvable Navigation Compasses for the given ring groups and speeds.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 校验参数
+		if len(flagSpeeds) != 3 {
+			return fmt.Errorf("invalid speeds: %v (expected speeds of outer, middle and inner rings)", flagSpeeds)
+		}
+		// 文本格式即 CSV ，兼容已废弃的 --output 参数
+		format := options.Format()
+		if cmd.Flags().Changed("output") {
+			if flagOutput != "csv" && flagOutput != options.FormatJSON {
+				return fmt.Errorf("unknown output format: %s (must be one of [csv json])", flagOutput)
+			}
+			format = flagOutput
+		} else if format != options.FormatText && format != options.FormatJSON {
+			return fmt.Errorf("unknown output format: %s (must be one of [text json])", format)
+		}
+		if format == options.FormatText {
+			format = "csv"
+		}
+		rgs, err := compass.ParseRingGroups(options.ExpandAliases(flagGroups))
+		if err != nil {
+			logger.Error(err, "parse ring groups error")
+			return fmt.Errorf("parse ring groups error: %w", err)
+		}
+		// 创建求解器
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+
+		// 枚举所有可解的罗盘
+		enumerated, err := enumcache.Enumerate(cmd.Context(), logger, solver, rgs, [3]int{flagSpeeds[0], flagSpeeds[1], flagSpeeds[2]}, !flagNoCache)
+		if err != nil {
+			logger.Error(err, "enumerate compasses error")
+			return fmt.Errorf("enumerate compasses error: %w", err)
+		}
+		records := make([]record, len(enumerated))
+		for i, e := range enumerated {
+			records[i] = record{
+				Compass:  e.Compass.String(),
+				Solution: e.Solution.String(),
+				Moves:    e.Solution.TotalCount(),
+			}
+		}
+
+		// 输出
+		if format == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(records)
+		}
+		w := csv.NewWriter(os.Stdout)
+		_ = w.Write([]string{"compass", "solution", "moves"})
+		for _, r := range records {
+			_ = w.Write([]string{r.Compass, r.Solution, strconv.Itoa(r.Moves)})
+		}
+		w.Flush()
+		return w.Error()
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagGroups, "groups", "", "ring groups of the compass, e.g. \"oi,om,mi\"")
+	Cmd.Flags().IntSliceVar(&flagSpeeds, "speeds", nil, "speeds of outer, middle and inner rings, e.g. \"1,-4,2\"")
+	Cmd.Flags().StringVarP(&flagOutput, "output", "o", "csv", "output format, one of [csv json]")
+	Cmd.Flags().BoolVar(&flagNoCache, "no-cache", false, "always enumerate instead of using the results cached in the user config dir")
+	_ = Cmd.Flags().MarkDeprecated("output", "use --format instead")
+	_ = Cmd.MarkFlagRequired("groups")
+	_ = Cmd.MarkFlagRequired("speeds")
+}
@@ -0,0 +1,71 @@
+package exportimage
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/compassimage"
+)
+
+var (
+	flagSize int
+	flagOut  string
+)
+
+// Cmd export-image 命令
+var Cmd = &cobra.Command{
+	Use:   "export-image COMPASS_EXPRESSION",
+	Short: "Draw a Navigation Compass as a PNG image.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 解析输入罗盘
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+		if err := input.Validate(); err != nil {
+			logger.Error(err, "compass validation error")
+			return fmt.Errorf("compass validation error: %w", err)
+		}
+
+		// 输出到文件或标准输出
+		var out io.Writer = os.Stdout
+		var f *os.File
+		if flagOut != "-" {
+			if f, err = os.Create(flagOut); err != nil {
+				logger.Error(err, "create output file error")
+				return fmt.Errorf("create output file error: %w", err)
+			}
+			out = f
+		}
+
+		if err := compassimage.Encode(out, &input, flagSize); err != nil {
+			if f != nil {
+				_ = f.Close()
+			}
+			logger.Error(err, "export image error")
+			return fmt.Errorf("export image error: %w", err)
+		}
+		if f != nil {
+			// 关闭失败时文件可能没有完整写入
+			if err := f.Close(); err != nil {
+				logger.Error(err, "close output file error")
+				return fmt.Errorf("close output file error: %w", err)
+			}
+			logger.Info(fmt.Sprintf("image of compass %s written to %s", input.String(), flagOut))
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().IntVar(&flagSize, "size", 256, "width and height of the image in pixels")
+	Cmd.Flags().StringVarP(&flagOut, "out", "o", "compass.png", "output file, \"-\" for stdout")
+}
@@ -0,0 +1,117 @@
+package fix
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagMax int
+)
+
+// suggestion 以 JSON 格式输出的一处修正
+type suggestion struct {
+	Compass  string `json:"compass"`
+	Change   string `json:"change"`
+	Cost     int    `json:"cost"`
+	Solution string `json:"solution"`
+	Moves    int    `json:"moves"`
+}
+
+// result 以 JSON 格式输出的结果
+type result struct {
+	Compass string `json:"compass"`
+	// 罗盘无解的原因，罗盘有解时为空
+	Unsolvable  string       `json:"unsolvable,omitempty"`
+	Suggestions []suggestion `json:"suggestions"`
+}
+
+// Cmd fix 命令
+var Cmd = &cobra.Command{
+	Use:   "fix COMPASS_EXPRESSION",
+	Short: "Suggest solvable compasses one typo away from an unsolvable Navigation Compass.",
+	Long: `Suggest solvable compasses one typo away from an unsolvable Navigation Compass.
+
+An unsolvable compass is most likely a typo in the transcription. The compasses
+differing in exactly one of the following are tried, the cost in parentheses:
+
+  - the location of one ring off by 1 (1)
+  - the direction of the speed of one ring flipped (1)
+  - the speed of one ring off by 1, still in 1-4 (2)
+  - one ring group missing (1)
+  - one extra ring group, which is not in any composite group (2)
+
+The solvable ones are listed by cost, then by the moves of the solution.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+		unsolvable := input.Solvability()
+		fixes, err := input.SuggestFixes(flagMax)
+		if err != nil {
+			logger.Error(err, "suggest fixes error")
+			return fmt.Errorf("suggest fixes error: %w", err)
+		}
+
+		ret := result{Compass: input.String(), Suggestions: []suggestion{}}
+		if unsolvable != nil {
+			ret.Unsolvable = unsolvable.Error()
+		}
+		for _, fix := range fixes {
+			ret.Suggestions = append(ret.Suggestions, suggestion{
+				Compass:  fix.Compass.String(),
+				Change:   fix.Change,
+				Cost:     fix.Cost,
+				Solution: fix.Solution.String(),
+				Moves:    fix.Solution.TotalCount(),
+			})
+		}
+		if err := printResult(ret); err != nil {
+			return err
+		}
+		if unsolvable != nil && len(fixes) == 0 {
+			return fmt.Errorf("%w: no solvable compass one typo away", compass.ErrUnsolvable)
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().IntVarP(&flagMax, "max", "n", 5, "maximum number of suggestions (0 for no limit)")
+}
+
+// printResult 按全局参数指定的格式输出结果
+func printResult(ret result) error {
+	if options.Format() == options.FormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(ret)
+	}
+	fmt.Printf("Compass:  %s\n", ret.Compass)
+	if ret.Unsolvable == "" {
+		fmt.Println("The compass is solvable, nothing to fix.")
+		return nil
+	}
+	fmt.Printf("Unsolvable: %s\n", ret.Unsolvable)
+	if len(ret.Suggestions) == 0 {
+		return nil
+	}
+	fmt.Println("Did you mean:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, s := range ret.Suggestions {
+		fmt.Fprintf(w, "  %s\t%s\t%s (%d moves)\n", s.Compass, s.Change, s.Solution, s.Moves)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagMaxNodes int
+)
+
+// result 以 JSON 格式输出的状态图，结构参见 compass.Node 及 compass.Edge
+type result struct {
+	Compass string         `json:"compass"`
+	Nodes   []compass.Node `json:"nodes"`
+	Edges   []compass.Edge `json:"edges"`
+	// 是否因超出 --max-nodes 而截断
+	Truncated bool `json:"truncated"`
+}
+
+// Cmd graph 命令
+var Cmd = &cobra.Command{
+	Use:   "graph COMPASS_EXPRESSION",
+	Short: "Output the graph of all states reachable from a Navigation Compass.",
+	Long: `Output the graph of all states reachable from a Navigation Compass.
+
+By default the graph is written in Graphviz DOT format, each node is labeled with
+the locations of the outer, middle and inner rings and each edge with the rotated
+ring group. With --format json it is written as JSON suitable for D3:
+
+  {"compass": "...", "nodes": [NODE...], "edges": [EDGE...], "truncated": false}
+
+where NODE is {"id", "outer", "middle", "inner", "depth", "solved"}, "id" being
+outer*36+middle*6+inner and "depth" the minimal moves from the initial state, and
+EDGE is {"from", "to", "group"}. Nodes are ordered by depth, the first one is the
+initial state. At most --max-nodes nodes of the smallest depth are kept, together
+with the edges between them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		if flagMaxNodes <= 0 {
+			return fmt.Errorf("invalid max nodes: %d (must be positive)", flagMaxNodes)
+		}
+		// 解析输入罗盘
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+		ret := result{Compass: input.String()}
+		ret.Nodes, ret.Edges = input.StateGraph()
+		ret.Nodes, ret.Edges, ret.Truncated = truncate(ret.Nodes, ret.Edges, flagMaxNodes)
+
+		// 输出
+		if options.Format() == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(ret)
+		}
+		fmt.Println("digraph compass {")
+		for _, n := range ret.Nodes {
+			attrs := ""
+			if n.Solved {
+				attrs = ", peripheries=2"
+			}
+			fmt.Printf("  %d [label=\"%d,%d,%d\"%s];\n", n.ID, n.Outer, n.Middle, n.Inner, attrs)
+		}
+		for _, e := range ret.Edges {
+			fmt.Printf("  %d -> %d [label=\"%s\"];\n", e.From, e.To, e.Group)
+		}
+		fmt.Println("}")
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().IntVar(&flagMaxNodes, "max-nodes", 216, "maximum number of nodes to output, nodes farther from the initial state are dropped first")
+}
+
+// truncate 只保留前 maxNodes 个状态及这些状态之间的状态转移，返回是否有状态被去掉
+func truncate(nodes []compass.Node, edges []compass.Edge, maxNodes int) ([]compass.Node, []compass.Edge, bool) {
+	if len(nodes) <= maxNodes {
+		return nodes, edges, false
+	}
+	nodes = nodes[:maxNodes]
+	kept := make(map[int]bool, len(nodes))
+	for _, n := range nodes {
+		kept[n.ID] = true
+	}
+	var filtered []compass.Edge
+	for _, e := range edges {
+		if kept[e.From] && kept[e.To] {
+			filtered = append(filtered, e)
+		}
+	}
+	return nodes, filtered, true
+}
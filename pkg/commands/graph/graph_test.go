@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestTruncate 测试 truncate
+func TestTruncate(t *testing.T) {
+	input, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	nodes, edges := input.StateGraph()
+
+	ret, retEdges, truncated := truncate(nodes, edges, len(nodes))
+	if truncated || len(ret) != len(nodes) || len(retEdges) != len(edges) {
+		t.Errorf("unexpected result: %d nodes, %d edges, %t (expected: %d nodes, %d edges, false)", len(ret), len(retEdges), truncated, len(nodes), len(edges))
+	}
+
+	ret, retEdges, truncated = truncate(nodes, edges, 2)
+	if !truncated || len(ret) != 2 || ret[0] != nodes[0] {
+		t.Errorf("unexpected result: %v, %t (expected the first 2 nodes truncated)", ret, truncated)
+	}
+	for _, e := range retEdges {
+		if (e.From != ret[0].ID && e.From != ret[1].ID) || (e.To != ret[0].ID && e.To != ret[1].ID) {
+			t.Errorf("unexpected edge: %+v (expected edges between the kept nodes only)", e)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package histogram
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/enumcache"
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+const (
+	// 文本柱状图的最大宽度
+	maxBarWidth = 50
+)
+
+var (
+	flagGroups  string
+	flagSpeeds  []int
+	flagOutput  string
+	flagNoCache bool
+)
+
+// bucket 柱状图中的一项
+type bucket struct {
+	Moves int `json:"moves"`
+	Count int `json:"count"`
+}
+
+// Cmd histogram 命令
+var Cmd = &cobra.Command{
+	Use:   "histogram",
+	Short: "Show the histogram of minimal moves of all solvable Navigation Compasses for the given ring groups and speeds.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 校验参数
+		if len(flagSpeeds) != 3 {
+			return fmt.Errorf("invalid speeds: %v (expected speeds of outer, middle and inner rings)", flagSpeeds)
+		}
+		// 兼容已废弃的 --output 参数
+		format := options.Format()
+		if cmd.Flags().Changed("output") {
+			format = flagOutput
+		}
+		if format != options.FormatText && format != options.FormatJSON {
+			return fmt.Errorf("unknown output format: %s (must be one of [text json])", format)
+		}
+		rgs, err := compass.ParseRingGroups(options.ExpandAliases(flagGroups))
+		if err != nil {
+			logger.Error(err, "parse ring groups error")
+			return fmt.Errorf("parse ring groups error: %w", err)
+		}
+		// 创建求解器
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+
+		// 枚举所有可解的罗盘
+		enumerated, err := enumcache.Enumerate(cmd.Context(), logger, solver, rgs, [3]int{flagSpeeds[0], flagSpeeds[1], flagSpeeds[2]}, !flagNoCache)
+		if err != nil {
+			logger.Error(err, "enumerate compasses error")
+			return fmt.Errorf("enumerate compasses error: %w", err)
+		}
+		// 按最少转动次数统计
+		var buckets []bucket
+		for _, e := range enumerated {
+			moves := e.Solution.TotalCount()
+			for len(buckets) <= moves {
+				buckets = append(buckets, bucket{Moves: len(buckets)})
+			}
+			buckets[moves].Count++
+		}
+
+		// 输出
+		if format == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(buckets)
+		}
+		maxCount := 0
+		for _, b := range buckets {
+			if b.Count > maxCount {
+				maxCount = b.Count
+			}
+		}
+		fmt.Printf("%5s %5s\n", "moves", "count")
+		for _, b := range buckets {
+			width := 0
+			if maxCount > 0 {
+				width = (b.Count*maxBarWidth + maxCount - 1) / maxCount
+			}
+			fmt.Printf("%5d %5d %s\n", b.Moves, b.Count, strings.Repeat("#", width))
+		}
+		fmt.Printf("total %5d\n", len(enumerated))
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagGroups, "groups", "", "ring groups of the compass, e.g. \"oi,om,mi\"")
+	Cmd.Flags().IntSliceVar(&flagSpeeds, "speeds", nil, "speeds of outer, middle and inner rings, e.g. \"1,-4,2\"")
+	Cmd.Flags().StringVarP(&flagOutput, "output", "o", "text", "output format, one of [text json]")
+	Cmd.Flags().BoolVar(&flagNoCache, "no-cache", false, "always enumerate instead of using the results cached in the user config dir")
+	_ = Cmd.Flags().MarkDeprecated("output", "use --format instead")
+	_ = Cmd.MarkFlagRequired("groups")
+	_ = Cmd.MarkFlagRequired("speeds")
+}
@@ -0,0 +1,59 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/store"
+)
+
+// Cmd list 命令
+var Cmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the Navigation Compasses saved in the local store.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		s, err := store.Default()
+		if err != nil {
+			logger.Error(err, "open store error")
+			return fmt.Errorf("open store error: %w", err)
+		}
+		all, err := s.All()
+		if err != nil {
+			logger.Error(err, "list compasses error")
+			return fmt.Errorf("list compasses error: %w", err)
+		}
+
+		return printAll(os.Stdout, all, options.Format())
+	},
+}
+
+// printAll 按 format 输出所有已保存的罗盘， format 为 options.FormatJSON 时输出名称到 JSON 表示的对象，
+// 否则按名称升序每行输出名称及罗盘的字符串表示
+func printAll(w io.Writer, all map[string]compass.Compass, format string) error {
+	if format == options.FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(all)
+	}
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		c := all[name]
+		fmt.Fprintf(tw, "%s\t%s\n", name, c.String())
+	}
+	return tw.Flush()
+}
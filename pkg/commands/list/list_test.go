@@ -0,0 +1,56 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestPrintAll 测试 printAll
+func TestPrintAll(t *testing.T) {
+	all := map[string]compass.Compass{}
+	for name, expr := range map[string]string{
+		"room2":   "3+1,0-2,0+2/o",
+		"room1":   "0+1,4-4,0+2/oi,om,mi",
+		"room-10": "3+1,0-2,0+0/o,mi",
+	} {
+		c, err := compass.ParseCompass(expr)
+		if err != nil {
+			t.Fatalf("parse compass %s error: %s", expr, err)
+		}
+		all[name] = c
+	}
+
+	// 按名称升序输出
+	var buf bytes.Buffer
+	if err := printAll(&buf, all, options.FormatText); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "room-10  3+1,0-2,0+0/mi,o\n" +
+		"room1    0+1,4-4,0+2/mi,oi,om\n" +
+		"room2    3+1,0-2,0+2/o\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", buf.String(), expected)
+	}
+
+	// JSON 格式的输出可以解析回所有罗盘
+	buf.Reset()
+	if err := printAll(&buf, all, options.FormatJSON); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var ret map[string]compass.Compass
+	if err := json.Unmarshal(buf.Bytes(), &ret); err != nil {
+		t.Fatalf("unmarshal %s error: %s", buf.String(), err)
+	}
+	if len(ret) != len(all) {
+		t.Errorf("unexpected result: %d compasses (expected: %d)", len(ret), len(all))
+	}
+	for name, c := range all {
+		if r, ok := ret[name]; !ok || !r.Equal(&c) {
+			t.Errorf("unexpected compass %s: %s (expected: %s)", name, r.String(), c.String())
+		}
+	}
+}
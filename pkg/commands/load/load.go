@@ -0,0 +1,54 @@
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/store"
+)
+
+// Cmd load 命令
+var Cmd = &cobra.Command{
+	Use:   "load NAME",
+	Short: "Print a Navigation Compass saved in the local store.",
+	Long: `Print a Navigation Compass saved in the local store by "save".
+
+The compass expression is printed, or the JSON representation with --format json.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		s, err := store.Default()
+		if err != nil {
+			logger.Error(err, "open store error")
+			return fmt.Errorf("open store error: %w", err)
+		}
+		c, err := s.Get(args[0])
+		if err != nil {
+			logger.Error(err, "load compass error")
+			return fmt.Errorf("load compass error: %w", err)
+		}
+
+		return printCompass(os.Stdout, c, options.Format())
+	},
+}
+
+// printCompass 按 format 输出罗盘， format 为 options.FormatJSON 时输出 JSON 表示，否则输出文本表示
+func printCompass(w io.Writer, c compass.Compass, format string) error {
+	if format == options.FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(c)
+	}
+	text, err := c.MarshalText()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(text))
+	return err
+}
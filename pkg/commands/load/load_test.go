@@ -0,0 +1,58 @@
+package load
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestPrintCompass 测试 printCompass
+func TestPrintCompass(t *testing.T) {
+	c, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	effect := *c.Clone()
+	effect.GroupEffect = map[compass.RingGroup][3]int{compass.OuterMiddleRingGroup: {1, 2, 0}}
+
+	for _, tc := range []struct {
+		compass  compass.Compass
+		format   string
+		expected string
+	}{
+		{compass: c, format: options.FormatText, expected: "0+1,4-4,0+2/mi,oi,om\n"},
+		// 有圈分组位移时字符串表示无法表示，输出 JSON 表示
+		{compass: effect, format: options.FormatText, expected: `{"outer":{"location":0,"speed":1},"middle":{"location":4,"speed":-4},"inner":{"location":0,"speed":2},"groups":["mi","oi","om"],"effects":{"om":[1,2,0]}}` + "\n"},
+		{compass: c, format: options.FormatJSON, expected: `{
+  "outer": {
+    "location": 0,
+    "speed": 1
+  },
+  "middle": {
+    "location": 4,
+    "speed": -4
+  },
+  "inner": {
+    "location": 0,
+    "speed": 2
+  },
+  "groups": [
+    "mi",
+    "oi",
+    "om"
+  ]
+}
+`},
+	} {
+		var buf bytes.Buffer
+		if err := printCompass(&buf, tc.compass, tc.format); err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+		if buf.String() != tc.expected {
+			t.Errorf("unexpected result: %#v (expected: %#v)", buf.String(), tc.expected)
+		}
+	}
+}
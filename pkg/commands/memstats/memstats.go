@@ -0,0 +1,116 @@
+package memstats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagCount    int
+	flagReusable bool
+)
+
+// result 内存统计结果，单位均为字节
+type result struct {
+	Compass string `json:"compass"`
+	Solves  int    `json:"solves"`
+	// 求解前后（均已 GC ）的堆内存占用，两者之差即求解后仍未释放的内存
+	HeapAllocBefore uint64 `json:"heap_alloc_before"`
+	HeapAllocAfter  uint64 `json:"heap_alloc_after"`
+	// 所有求解累计分配的内存及分配次数
+	TotalAlloc uint64 `json:"total_alloc"`
+	Mallocs    uint64 `json:"mallocs"`
+	// 平均每次求解分配的内存及分配次数
+	AllocPerSolve   uint64 `json:"alloc_per_solve"`
+	MallocsPerSolve uint64 `json:"mallocs_per_solve"`
+}
+
+// Cmd memstats 命令
+var Cmd = &cobra.Command{
+	Use:   "memstats COMPASS_EXPRESSION",
+	Short: "Solve a Navigation Compass repeatedly and report the memory usage of the solver.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		if flagCount <= 0 {
+			return fmt.Errorf("invalid count: %d (must be positive)", flagCount)
+		}
+		// 创建求解器
+		newSolver := compass.NewDefaultSolver
+		if flagReusable {
+			newSolver = compass.NewReusableSolver
+		}
+		solver, err := newSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+		// 解析输入罗盘
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+
+		ret, err := measure(cmd.Context(), solver, input, flagCount)
+		if err != nil {
+			logger.Error(err, "solve navigation compass error")
+			return fmt.Errorf("solve navigation compass error: %w", err)
+		}
+
+		// 输出
+		if options.Format() == options.FormatJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(ret)
+		}
+		fmt.Printf("Compass:            %s\n", ret.Compass)
+		fmt.Printf("Solves:             %d\n", ret.Solves)
+		fmt.Printf("Heap alloc before:  %d B\n", ret.HeapAllocBefore)
+		fmt.Printf("Heap alloc after:   %d B\n", ret.HeapAllocAfter)
+		fmt.Printf("Total alloc:        %d B (%d mallocs)\n", ret.TotalAlloc, ret.Mallocs)
+		fmt.Printf("Alloc per solve:    %d B (%d mallocs)\n", ret.AllocPerSolve, ret.MallocsPerSolve)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().IntVarP(&flagCount, "count", "n", 1000, "number of solves")
+	Cmd.Flags().BoolVar(&flagReusable, "reusable", false, "use the solver reusing its buffers between solves instead of the default solver")
+}
+
+// measure 使用 solver 求解 count 次罗盘，统计求解前后的内存占用及求解过程中的内存分配
+func measure(ctx context.Context, solver compass.Solver, input compass.Compass, count int) (result, error) {
+	ret := result{Compass: input.String(), Solves: count}
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := 0; i < count; i++ {
+		if _, err := solver.Solve(ctx, input); err != nil {
+			return ret, err
+		}
+	}
+	// 先读取累计分配，再 GC 后读取仍未释放的内存
+	runtime.ReadMemStats(&after)
+	ret.TotalAlloc = after.TotalAlloc - before.TotalAlloc
+	ret.Mallocs = after.Mallocs - before.Mallocs
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	ret.HeapAllocBefore = before.HeapAlloc
+	ret.HeapAllocAfter = after.HeapAlloc
+	ret.AllocPerSolve = ret.TotalAlloc / uint64(count)
+	ret.MallocsPerSolve = ret.Mallocs / uint64(count)
+	return ret, nil
+}
@@ -0,0 +1,39 @@
+package memstats
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestMeasure 测试 measure
+func TestMeasure(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new solver error: %s", err)
+	}
+	input, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	ret, err := measure(context.Background(), solver, input, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ret.Solves != 10 || ret.Compass != "0+1,4-4,0+2/mi,oi,om" {
+		t.Errorf("unexpected result: %+v", ret)
+	}
+	if ret.TotalAlloc == 0 || ret.AllocPerSolve != ret.TotalAlloc/10 {
+		t.Errorf("unexpected alloc: %d total, %d per solve", ret.TotalAlloc, ret.AllocPerSolve)
+	}
+
+	// 无解时返回错误
+	input.MiddleRing.Location = 3
+	if _, err := measure(context.Background(), solver, input, 10); !errors.Is(err, compass.ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, compass.ErrUnsolvable)
+	}
+}
@@ -0,0 +1,122 @@
+package options
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// DefaultAliases 默认的圈分组别名，对应游戏中不同罗盘外观下玩家对各圈的常见叫法
+// 上、中、下分别为外圈、中圈、内圈；红、绿、蓝为各圈指针的颜色（参见 compassimage ）
+var DefaultAliases = map[string]string{
+	"outer":  "o",
+	"middle": "m",
+	"inner":  "i",
+	"top":    "o",
+	"bottom": "i",
+	"red":    "o",
+	"green":  "m",
+	"blue":   "i",
+}
+
+var (
+	flagAlias map[string]string
+)
+
+// validateAliases 校验 --alias 指定的别名，别名的值必须是圈分组
+func validateAliases() error {
+	for alias, name := range flagAlias {
+		if _, err := compass.ParseRingGroup(name); err != nil {
+			return fmt.Errorf("invalid alias %s=%s: %w", alias, name, err)
+		}
+	}
+	return nil
+}
+
+// Aliases 返回所有圈分组别名，即 DefaultAliases 加上 --alias 指定的别名，别名均为小写
+func Aliases() map[string]string {
+	ret := make(map[string]string, len(DefaultAliases)+len(flagAlias))
+	for alias, name := range DefaultAliases {
+		ret[alias] = name
+	}
+	for alias, name := range flagAlias {
+		ret[strings.ToLower(strings.TrimSpace(alias))] = strings.TrimSpace(name)
+	}
+	return ret
+}
+
+// ExpandAliases 将罗盘表达式（ / 之后的部分）、圈分组列表或单个圈的名称中的别名替换为圈分组简写名，其余部分保持不变
+// 各圈的别名以 - 连接表示多个圈组成的圈分组，比如 "top-bottom" 或 "red-blue" 即 "oi" 。
+// 不认识的名称保持不变，交给解析时报错；以 { 开头的 JSON 表示不做处理
+func ExpandAliases(expr string) string {
+	if strings.HasPrefix(strings.TrimSpace(expr), "{") {
+		return expr
+	}
+	// 以 ; 分隔的多个罗盘分别替换，参见 compass.ParsePuzzle
+	if strings.Contains(expr, ";") {
+		parts := strings.Split(expr, ";")
+		for i := range parts {
+			parts[i] = ExpandAliases(parts[i])
+		}
+		return strings.Join(parts, ";")
+	}
+	prefix, groups := "", expr
+	if i := strings.LastIndex(expr, "/"); i >= 0 {
+		prefix, groups = expr[:i+1], expr[i+1:]
+	}
+	aliases := Aliases()
+
+	// 逐个由字母和 - 组成的名称替换
+	var b strings.Builder
+	b.WriteString(prefix)
+	isNameRune := func(r rune) bool { return unicode.IsLetter(r) || r == '-' }
+	for len(groups) > 0 {
+		end := strings.IndexFunc(groups, func(r rune) bool { return !isNameRune(r) })
+		if end < 0 {
+			end = len(groups)
+		}
+		if end == 0 {
+			b.WriteByte(groups[0])
+			groups = groups[1:]
+			continue
+		}
+		b.WriteString(expandName(groups[:end], aliases))
+		groups = groups[end:]
+	}
+	return b.String()
+}
+
+// expandName 替换一个圈分组名称中的别名，有任意一部分不认识时原样返回
+func expandName(name string, aliases map[string]string) string {
+	parts := strings.Split(name, "-")
+	expanded := make([]string, len(parts))
+	for i, part := range parts {
+		if v, ok := aliases[strings.ToLower(part)]; ok {
+			expanded[i] = v
+			continue
+		}
+		// 与别名连接的也可以是圈分组简写名，比如 "o-bottom"
+		if _, err := compass.ParseRingGroup(part); err == nil {
+			expanded[i] = part
+			continue
+		}
+		if len(parts) > 1 {
+			return name
+		}
+		expanded[i] = part
+	}
+	return strings.Join(expanded, "")
+}
+
+// aliasNames 返回所有别名，按字母序排列，用于参数说明
+func aliasNames(aliases map[string]string) []string {
+	ret := make([]string, 0, len(aliases))
+	for alias, name := range aliases {
+		ret = append(ret, alias+"="+name)
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,46 @@
+package options
+
+import (
+	"testing"
+)
+
+// TestExpandAliases 测试 ExpandAliases
+func TestExpandAliases(t *testing.T) {
+	flagAlias = map[string]string{"Left": "om"}
+	defer func() { flagAlias = nil }()
+
+	for expr, expected := range map[string]string{
+		// 罗盘表达式只替换圈分组部分
+		"0+1,4-4,0+2/top-bottom,Red-green,middle-blue": "0+1,4-4,0+2/oi,om,mi",
+		"0+1,4-4,0+2/oi,om,mi":                         "0+1,4-4,0+2/oi,om,mi",
+		"2-1,5+2,4+1/O,mi,(top+middle-inner)":          "2-1,5+2,4+1/O,mi,(o+mi)",
+		"3+1,0-2,5+0/o-bottom,left":                    "3+1,0-2,5+0/oi,om",
+		// 不认识的名称保持不变
+		"3+1,0-2,5+0/top-left-x,purple": "3+1,0-2,5+0/top-left-x,purple",
+		// 多个罗盘
+		"0+1,4-4,0+2/top;3+1,0-2,5+0/bottom": "0+1,4-4,0+2/o;3+1,0-2,5+0/i",
+		// 圈分组列表及单个圈
+		"top-middle,blue": "om,i",
+		"bottom":          "i",
+		// JSON 表示不处理
+		`{"groups":["top"]}`: `{"groups":["top"]}`,
+	} {
+		if ret := ExpandAliases(expr); ret != expected {
+			t.Errorf("unexpected result of %#v: %#v (expected: %#v)", expr, ret, expected)
+		}
+	}
+}
+
+// TestValidateAliases 测试 validateAliases
+func TestValidateAliases(t *testing.T) {
+	defer func() { flagAlias = nil }()
+
+	flagAlias = map[string]string{"top": "o", "diag": "oi"}
+	if err := validateAliases(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	flagAlias = map[string]string{"top": "x"}
+	if err := validateAliases(); err == nil {
+		t.Errorf("expected error with invalid alias")
+	}
+}
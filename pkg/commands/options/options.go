@@ -0,0 +1,79 @@
+// Package options 所有子命令共享的全局选项
+// 全局选项以持久化参数的形式注册在根命令上，根命令的 PersistentPreRunE 调用 Setup 后，
+// 子命令即可通过本包的函数读取，无需各自重复声明
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bombsimon/logrusr/v4"
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// 输出格式的合法值
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatEmoji = "emoji"
+)
+
+// formats 输出格式的合法值
+var formats = []string{FormatText, FormatJSON, FormatEmoji}
+
+var (
+	flagVerbose int
+	flagFormat  string
+)
+
+// AddFlags 将全局选项注册为参数
+func AddFlags(fs *pflag.FlagSet) {
+	fs.CountVarP(&flagVerbose, "verbose", "v", "number for the log level verbosity")
+	fs.StringVar(&flagFormat, "format", FormatText, fmt.Sprintf("output format, one of %v", formats))
+	fs.StringToStringVar(&flagAlias, "alias", nil, fmt.Sprintf("extra aliases of ring groups accepted in compass expressions and ring group arguments, e.g. \"top=o,bottom=i\" (defaults: %s)", strings.Join(aliasNames(DefaultAliases), ",")))
+}
+
+// Setup 校验全局选项并据此完成全局设置，比如日志级别
+func Setup() error {
+	valid := false
+	for _, f := range formats {
+		if flagFormat == f {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown output format: %s (must be one of %v)", flagFormat, formats)
+	}
+	if err := validateAliases(); err != nil {
+		return err
+	}
+
+	switch flagVerbose {
+	case 0:
+		logrus.SetLevel(logrus.InfoLevel)
+	case 1:
+		logrus.SetLevel(logrus.DebugLevel)
+	default:
+		logrus.SetLevel(logrus.TraceLevel)
+	}
+	return nil
+}
+
+// Verbose 返回日志详细程度，即 -v 参数出现的次数
+func Verbose() int {
+	return flagVerbose
+}
+
+// Format 返回输出格式，为 FormatText 、 FormatJSON 或 FormatEmoji
+// 不支持该格式的子命令应当报错或按 FormatText 输出
+func Format() string {
+	return flagFormat
+}
+
+// Logger 返回子命令使用的日志记录器
+func Logger() logr.Logger {
+	return logrusr.New(logrus.StandardLogger())
+}
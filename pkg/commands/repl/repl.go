@@ -0,0 +1,284 @@
+package repl
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+const (
+	// 默认历史记录文件名，位于用户主目录下
+	defaultHistoryFileName = ".hksr-compass_history"
+	// 提示符
+	prompt = "> "
+)
+
+var (
+	flagHistoryFile string
+)
+
+// Cmd repl 命令
+var Cmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Start an interactive workspace for working through a Navigation Compass step by step.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 创建求解器
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+
+		historyFile := flagHistoryFile
+		if !cmd.Flags().Changed("history-file") {
+			if home, err := os.UserHomeDir(); err == nil {
+				historyFile = filepath.Join(home, defaultHistoryFileName)
+			}
+		}
+		s := newSession(logger, solver, os.Stdout, historyFile)
+		fmt.Fprintln(os.Stdout, "type \"help\" for available commands, \"quit\" or Ctrl-D to exit")
+		err = s.run(cmd.Context(), os.Stdin)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagHistoryFile, "history-file", "", "file to persist command history in, empty to disable (default \"~/"+defaultHistoryFileName+"\")")
+}
+
+// session 一次交互会话
+type session struct {
+	logger logr.Logger
+	solver compass.Solver
+	out    io.Writer
+	// 历史记录文件，为空时不持久化历史记录
+	historyFile string
+	// 执行过的命令，包括之前会话中的命令
+	history []string
+	// 罗盘状态，第一个元素为载入时的罗盘，最后一个元素为当前罗盘
+	states []*compass.Compass
+}
+
+// newSession 创建交互会话，并从历史记录文件中载入之前会话的命令
+func newSession(logger logr.Logger, solver compass.Solver, out io.Writer, historyFile string) *session {
+	s := &session{
+		logger:      logger,
+		solver:      solver,
+		out:         out,
+		historyFile: historyFile,
+	}
+	if historyFile == "" {
+		return s
+	}
+	data, err := os.ReadFile(historyFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Error(err, "read history file error")
+		}
+		return s
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			s.history = append(s.history, line)
+		}
+	}
+	return s
+}
+
+// run 逐行读取并执行命令，直到输入结束、执行了 quit 命令或上下文被取消
+func (s *session) run(ctx context.Context, in io.Reader) error {
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		fmt.Fprint(s.out, prompt)
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(s.out)
+			return ctx.Err()
+		case err := <-readErr:
+			fmt.Fprintln(s.out)
+			return err
+		case line := <-lines:
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			s.appendHistory(line)
+			quit, err := s.exec(ctx, line)
+			if err != nil {
+				fmt.Fprintf(s.out, "Error: %s\n", err)
+			}
+			if quit {
+				return nil
+			}
+		}
+	}
+}
+
+// appendHistory 记录命令，并追加到历史记录文件
+func (s *session) appendHistory(line string) {
+	s.history = append(s.history, line)
+	if s.historyFile == "" {
+		return
+	}
+	f, err := os.OpenFile(s.historyFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		s.logger.Error(err, "open history file error")
+		return
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, line); err != nil {
+		s.logger.Error(err, "write history file error")
+	}
+}
+
+// current 返回当前罗盘，尚未载入罗盘时返回错误
+func (s *session) current() (*compass.Compass, error) {
+	if len(s.states) == 0 {
+		return nil, errors.New("no compass loaded, use \"load COMPASS_EXPRESSION\" first")
+	}
+	return s.states[len(s.states)-1], nil
+}
+
+// exec 执行一条命令，返回是否退出会话
+func (s *session) exec(ctx context.Context, line string) (bool, error) {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "help":
+		fmt.Fprint(s.out, helpText)
+	case "quit", "exit":
+		return true, nil
+	case "history":
+		for i, h := range s.history {
+			fmt.Fprintf(s.out, "%4d  %s\n", i+1, h)
+		}
+	case "load":
+		c, err := compass.ParseCompass(options.ExpandAliases(arg))
+		if err != nil {
+			return false, fmt.Errorf("parse compass error: %w", err)
+		}
+		if err := c.Validate(); err != nil {
+			return false, fmt.Errorf("compass validation error: %w", err)
+		}
+		s.states = []*compass.Compass{c.Clone()}
+		fmt.Fprintf(s.out, "Compass:  %s\n", c.String())
+	case "rotate":
+		cur, err := s.current()
+		if err != nil {
+			return false, err
+		}
+		// 省略转动次数时转动一次
+		if arg != "" && !strings.ContainsAny(arg[len(arg)-1:], "0123456789") {
+			arg += "1"
+		}
+		step, err := compass.ParseStep(arg)
+		if err != nil {
+			return false, fmt.Errorf("parse step error: %w", err)
+		}
+		next := cur.Clone()
+		if err := next.ApplySteps(compass.Steps{step}); err != nil {
+			return false, err
+		}
+		s.states = append(s.states, next)
+		s.printCurrent()
+	case "undo":
+		if _, err := s.current(); err != nil {
+			return false, err
+		}
+		if len(s.states) == 1 {
+			return false, errors.New("nothing to undo")
+		}
+		s.states = s.states[:len(s.states)-1]
+		s.printCurrent()
+	case "reset":
+		if _, err := s.current(); err != nil {
+			return false, err
+		}
+		s.states = s.states[:1]
+		s.printCurrent()
+	case "render":
+		cur, err := s.current()
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintln(s.out, cur.Render())
+	case "solve", "hint":
+		cur, err := s.current()
+		if err != nil {
+			return false, err
+		}
+		solution, err := s.solver.Solve(ctx, *cur)
+		if err != nil {
+			return false, fmt.Errorf("solve navigation compass error: %w", err)
+		}
+		if name == "solve" {
+			fmt.Fprintf(s.out, "Solution: %s\n", solution.String())
+			break
+		}
+		if len(solution) == 0 {
+			fmt.Fprintln(s.out, "Hint:     already solved")
+			break
+		}
+		hint := solution[0]
+		hint.Count = 1
+		fmt.Fprintf(s.out, "Hint:     rotate %s (%d moves left)\n", hint.String(), solution.TotalCount())
+	default:
+		return false, fmt.Errorf("unknown command: %s (type \"help\" for available commands)", name)
+	}
+	return false, nil
+}
+
+// printCurrent 输出当前罗盘
+func (s *session) printCurrent() {
+	cur := s.states[len(s.states)-1]
+	if cur.IsSolved() {
+		fmt.Fprintf(s.out, "Compass:  %s (solved)\n", cur.String())
+		return
+	}
+	fmt.Fprintf(s.out, "Compass:  %s\n", cur.String())
+}
+
+// helpText 帮助信息
+const helpText = `Commands:
+  load COMPASS_EXPRESSION  load a compass, e.g. "load 0+1,4-4,0+2/oi,om,mi"
+  rotate STEP              rotate the current compass, e.g. "rotate om", "rotate om2" or "rotate (mi+o)"
+  solve                    show the solution of the current compass
+  hint                     show the next move of the solution
+  undo                     undo the last rotation
+  reset                    go back to the loaded compass
+  render                   render the current compass as ASCII art
+  history                  show the command history
+  help                     show this help
+  quit                     exit
+`
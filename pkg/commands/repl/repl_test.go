@@ -0,0 +1,106 @@
+package repl
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestSession 测试交互会话
+func TestSession(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	historyFile := filepath.Join(t.TempDir(), "history")
+
+	input := strings.Join([]string{
+		"solve",
+		"load 0+1,4-4,0+2/oi,om,mi",
+		"hint",
+		"rotate mi2",
+		"rotate oi",
+		"undo",
+		"rotate oi4",
+		"rotate om2",
+		"reset",
+		"rotate o",
+		"solve",
+		"quit",
+		"load 0+1,0+1,0+1/o",
+	}, "\n")
+	var out bytes.Buffer
+	s := newSession(logr.Discard(), solver, &out, historyFile)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.run(ctx, strings.NewReader(input)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := strings.Join([]string{
+		"> Error: no compass loaded, use \"load COMPASS_EXPRESSION\" first",
+		"> Compass:  0+1,4-4,0+2/mi,oi,om",
+		"> Hint:     rotate mi1 (8 moves left)",
+		"> Compass:  0+1,2-4,4+2/mi,oi,om",
+		"> Compass:  1+1,2-4,0+2/mi,oi,om",
+		"> Compass:  0+1,2-4,4+2/mi,oi,om",
+		"> Compass:  4+1,2-4,0+2/mi,oi,om",
+		"> Compass:  0+1,0-4,0+2/mi,oi,om (solved)",
+		"> Compass:  0+1,4-4,0+2/mi,oi,om",
+		"> Error: ring group not supported by compass: Outer (must be one of [OuterInner OuterMiddle MiddleInner])",
+		"> Solution: mi2,oi4,om2",
+		"> ",
+	}, "\n")
+	got := out.String()
+	if got != expected {
+		t.Errorf("unexpected output: %q (expected: %q)", got, expected)
+	}
+	// quit 之后的命令不会执行
+	if strings.Contains(got, "0+1,0+1,0+1") {
+		t.Errorf("unexpected output after quit: %q", got)
+	}
+
+	// 历史记录在会话之间保留
+	data, err := os.ReadFile(historyFile)
+	if err != nil {
+		t.Fatalf("read history file error: %s", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 12 {
+		t.Errorf("unexpected history lines: %d (expected: %d)", len(lines), 12)
+	}
+	next := newSession(logr.Discard(), solver, &out, historyFile)
+	if len(next.history) != 12 || next.history[1] != "load 0+1,4-4,0+2/oi,om,mi" {
+		t.Errorf("unexpected history of next session: %#v", next.history)
+	}
+}
+
+// TestSessionCancel 测试上下文被取消时会话结束
+func TestSessionCancel(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	// 永远不会结束的输入
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("create pipe error: %s", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	var out bytes.Buffer
+	s := newSession(logr.Discard(), solver, &out, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := s.run(ctx, r); err != context.DeadlineExceeded {
+		t.Errorf("unexpected error: %v (expected: %s)", err, context.DeadlineExceeded)
+	}
+}
@@ -1,41 +1,70 @@
 package commands
 
 import (
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/keybrl/hksr-compass/pkg/commands/benchparse"
+	"github.com/keybrl/hksr-compass/pkg/commands/check"
+	"github.com/keybrl/hksr-compass/pkg/commands/compare"
+	"github.com/keybrl/hksr-compass/pkg/commands/daily"
+	"github.com/keybrl/hksr-compass/pkg/commands/enumerate"
+	"github.com/keybrl/hksr-compass/pkg/commands/exportimage"
+	"github.com/keybrl/hksr-compass/pkg/commands/fix"
+	"github.com/keybrl/hksr-compass/pkg/commands/graph"
+	"github.com/keybrl/hksr-compass/pkg/commands/histogram"
+	"github.com/keybrl/hksr-compass/pkg/commands/list"
+	"github.com/keybrl/hksr-compass/pkg/commands/load"
+	"github.com/keybrl/hksr-compass/pkg/commands/memstats"
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/commands/repl"
+	"github.com/keybrl/hksr-compass/pkg/commands/save"
+	"github.com/keybrl/hksr-compass/pkg/commands/schema"
+	"github.com/keybrl/hksr-compass/pkg/commands/serve"
 	"github.com/keybrl/hksr-compass/pkg/commands/solve"
+	"github.com/keybrl/hksr-compass/pkg/commands/stats"
+	"github.com/keybrl/hksr-compass/pkg/commands/verify"
+	"github.com/keybrl/hksr-compass/pkg/commands/watch"
+	"github.com/keybrl/hksr-compass/pkg/commands/why"
 )
 
 const (
 	binName = "hksr-compass"
 )
 
-var (
-	flagVerbose int
-)
-
 // Cmd 根命令
 var Cmd = &cobra.Command{
 	Use:   binName,
 	Short: "A tool for solving the Navigation Compass in the game Honkai: Star Rail.",
+	// 在任何子命令执行前完成全局设置
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-		switch flagVerbose {
-		case 2:
-			logrus.SetLevel(logrus.TraceLevel)
-		case 1:
-			logrus.SetLevel(logrus.DebugLevel)
-		default:
-			logrus.SetLevel(logrus.InfoLevel)
-		}
-		return nil
+		return options.Setup()
 	},
 }
 
 func init() {
-	Cmd.PersistentFlags().CountVarP(&flagVerbose, "verbose", "v", "number for the log level verbosity")
+	options.AddFlags(Cmd.PersistentFlags())
 
 	Cmd.AddCommand(
 		solve.Cmd,
+		enumerate.Cmd,
+		histogram.Cmd,
+		verify.Cmd,
+		watch.Cmd,
+		stats.Cmd,
+		daily.Cmd,
+		serve.Cmd,
+		exportimage.Cmd,
+		repl.Cmd,
+		compare.Cmd,
+		memstats.Cmd,
+		graph.Cmd,
+		check.Cmd,
+		save.Cmd,
+		load.Cmd,
+		list.Cmd,
+		why.Cmd,
+		schema.Cmd,
+		benchparse.Cmd,
+		fix.Cmd,
 	)
 }
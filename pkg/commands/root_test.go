@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+)
+
+// TestGlobalFlags 测试子命令可以读取在根命令上设置的全局选项
+func TestGlobalFlags(t *testing.T) {
+	var verbose int
+	var format string
+	probe := &cobra.Command{
+		Use: "probe",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose = options.Verbose()
+			format = options.Format()
+			return nil
+		},
+	}
+	Cmd.AddCommand(probe)
+	defer Cmd.RemoveCommand(probe)
+	defer Cmd.SetArgs(nil)
+
+	// 全局选项写在子命令前后都可以
+	for _, args := range [][]string{
+		{"-vv", "--format", "json", "probe"},
+		{"probe", "--format", "json", "-vv"},
+	} {
+		verbose, format = 0, ""
+		// 计数参数在多次执行间累加，需要重置
+		_ = Cmd.PersistentFlags().Set("verbose", "0")
+		Cmd.SetArgs(args)
+		if err := Cmd.Execute(); err != nil {
+			t.Errorf("unexpected error of %v: %s", args, err)
+			continue
+		}
+		if verbose != 2 || format != options.FormatJSON {
+			t.Errorf("unexpected result of %v: %d, %s (expected: %d, %s)", args, verbose, format, 2, options.FormatJSON)
+		}
+	}
+
+	// 非法的全局选项在子命令执行前报错
+	format = ""
+	Cmd.SetArgs([]string{"--format", "xml", "probe"})
+	Cmd.SilenceUsage, Cmd.SilenceErrors = true, true
+	defer func() { Cmd.SilenceUsage, Cmd.SilenceErrors = false, false }()
+	if err := Cmd.Execute(); err == nil {
+		t.Errorf("expected error with unknown format")
+	}
+	if format != "" {
+		t.Errorf("unexpected subcommand run with unknown format")
+	}
+}
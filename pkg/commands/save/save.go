@@ -0,0 +1,62 @@
+package save
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/store"
+)
+
+var (
+	flagNotches bool
+)
+
+// Cmd save 命令
+var Cmd = &cobra.Command{
+	Use:   "save NAME COMPASS_EXPRESSION",
+	Short: "Save a Navigation Compass by name in the local store.",
+	Long: `Save a Navigation Compass by name in the local store.
+
+The store is a JSON file in the user config dir, e.g.
+~/.config/hksr-compass/compasses.json on Linux. A compass with the same name is
+overwritten. A saved compass can be solved by "solve @NAME".`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		parseCompass := compass.ParseCompass
+		if flagNotches {
+			parseCompass = compass.ParseCompassNotches
+		}
+		s, err := store.Default()
+		if err != nil {
+			logger.Error(err, "open store error")
+			return fmt.Errorf("open store error: %w", err)
+		}
+		input, err := save(s, args[0], options.ExpandAliases(args[1]), parseCompass)
+		if err != nil {
+			logger.Error(err, "save compass error")
+			return err
+		}
+		fmt.Printf("Saved %s: %s\n", args[0], input.String())
+		return nil
+	},
+}
+
+// save 使用 parseCompass 解析罗盘表达式，以指定名称保存到 s 中，返回保存的罗盘
+func save(s *store.Store, name, expr string, parseCompass func(string) (compass.Compass, error)) (compass.Compass, error) {
+	input, err := parseCompass(expr)
+	if err != nil {
+		return input, fmt.Errorf("parse compass error: %w", err)
+	}
+	if err := s.Save(name, input); err != nil {
+		return input, fmt.Errorf("save compass error: %w", err)
+	}
+	return input, nil
+}
+
+func init() {
+	Cmd.Flags().BoolVar(&flagNotches, "notches", false, "speeds in the compass expression are written as direction and notches, e.g. \"0cw1,4ccw4,0cw2/oi,om,mi\"")
+}
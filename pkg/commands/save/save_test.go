@@ -0,0 +1,48 @@
+package save
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/store"
+)
+
+// TestSave 测试 save
+func TestSave(t *testing.T) {
+	s := store.New(filepath.Join(t.TempDir(), "compasses.json"))
+	for _, tc := range []struct {
+		name        string
+		expr        string
+		parse       func(string) (compass.Compass, error)
+		expectedRet string
+	}{
+		{name: "room1", expr: "0+1,4-4,0+2/oi,om,mi", parse: compass.ParseCompass, expectedRet: "0+1,4-4,0+2/mi,oi,om"},
+		{name: "room2", expr: "3cw1,0ccw2,0cw2/o", parse: compass.ParseCompassNotches, expectedRet: "3+1,0-2,0+2/o"},
+		// 同名时覆盖
+		{name: "room1", expr: "3+1,0-2,0+0/o,mi", parse: compass.ParseCompass, expectedRet: "3+1,0-2,0+0/mi,o"},
+	} {
+		ret, err := save(s, tc.name, tc.expr, tc.parse)
+		if err != nil || ret.String() != tc.expectedRet {
+			t.Errorf("unexpected result of %#v: %#v, %v (expected: %#v)", tc.expr, ret.String(), err, tc.expectedRet)
+			continue
+		}
+		saved, err := s.Get(tc.name)
+		if err != nil || saved.String() != tc.expectedRet {
+			t.Errorf("unexpected saved compass %s: %#v, %v (expected: %#v)", tc.name, saved.String(), err, tc.expectedRet)
+		}
+	}
+
+	// 表达式不合法时不保存
+	if _, err := save(s, "room3", "0+1,4-4/oi", compass.ParseCompass); !errors.Is(err, compass.ErrParseFormat) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, compass.ErrParseFormat)
+	}
+	if _, err := s.Get("room3"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, store.ErrNotFound)
+	}
+	// 名称不合法
+	if _, err := save(s, "room 3", "0+1,4-4,0+2/oi", compass.ParseCompass); err == nil {
+		t.Errorf("unexpected result with invalid name: no error (expected an error)")
+	}
+}
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// Cmd schema 命令
+var Cmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the Navigation Compass JSON format.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		schema, err := compass.JSONSchema()
+		if err != nil {
+			logger.Error(err, "generate json schema error")
+			return fmt.Errorf("generate json schema error: %w", err)
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(schema))
+		return err
+	},
+}
@@ -0,0 +1,180 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagAddr         string
+	flagStepInterval time.Duration
+)
+
+// Cmd serve 命令
+var Cmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve an HTTP API for solving Navigation Compasses.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 创建求解器
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+
+		server := &http.Server{
+			Addr:    flagAddr,
+			Handler: newHandler(logger, solver, flagStepInterval),
+		}
+		// 命令被取消时关闭服务
+		go func() {
+			<-cmd.Context().Done()
+			_ = server.Close()
+		}()
+
+		logger.Info(fmt.Sprintf("listening on %s", flagAddr))
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error(err, "serve http error")
+			return fmt.Errorf("serve http error: %w", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagAddr, "addr", "127.0.0.1:8080", "address to listen on")
+	Cmd.Flags().DurationVar(&flagStepInterval, "step-interval", 0, "interval between streamed solution steps")
+}
+
+// newHandler 创建 HTTP 处理器
+func newHandler(logger logr.Logger, solver compass.Solver, stepInterval time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/solve/stream", &streamHandler{
+		logger:       logger,
+		solver:       solver,
+		stepInterval: stepInterval,
+	})
+	mux.HandleFunc("/schema", serveSchema)
+	return mux
+}
+
+// serveSchema 处理 GET /schema ，返回罗盘 JSON 表示的 JSON Schema ，参见 compass.JSONSchema
+func serveSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	schema, err := compass.JSONSchema()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("generate json schema error: %s", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	_, _ = w.Write(append(schema, '\n'))
+}
+
+// streamEvent 流式求解时推送的事件数据
+type streamEvent struct {
+	// 当前步骤，初始事件及结束事件中为空
+	Step string `json:"step,omitempty"`
+	// 转动当前步骤后的罗盘
+	Compass string `json:"compass,omitempty"`
+	// 完整解法，仅结束事件中有
+	Solution string `json:"solution,omitempty"`
+	// 错误信息，仅错误事件中有
+	Error string `json:"error,omitempty"`
+}
+
+// streamHandler 以 Server-Sent Events 流式推送解法的处理器
+// 请求形如 GET /solve/stream?compass=0+1,4-4,0+2/oi,om,mi ，依次推送以下事件：
+// 一个 compass 事件，内容为初始罗盘；每个步骤一个 step 事件，内容为该步骤及转动后的罗盘；
+// 最后一个 done 事件，内容为完整解法。出错时推送一个 error 事件并结束
+type streamHandler struct {
+	logger       logr.Logger
+	solver       compass.Solver
+	stepInterval time.Duration
+}
+
+// ServeHTTP 处理请求
+func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	input, err := compass.ParseCompass(options.ExpandAliases(r.URL.Query().Get("compass")))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("parse compass error: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	send := func(event string, data streamEvent) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		b, err := json.Marshal(data)
+		if err != nil {
+			h.logger.Error(err, "marshal event error")
+			return false
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, b); err != nil {
+			h.logger.V(1).Info(fmt.Sprintf("write event error: %s", err))
+			return false
+		}
+		flusher.Flush()
+		return true
+	}
+
+	if !send("compass", streamEvent{Compass: input.String()}) {
+		return
+	}
+	solution, err := h.solver.Solve(ctx, input)
+	if err != nil {
+		send("error", streamEvent{Error: compass.WrapSolveError(input, err).Error()})
+		return
+	}
+
+	cur := input.Clone()
+	for _, step := range solution {
+		// 按间隔推送，以便前端播放动画，客户端断开时停止
+		if h.stepInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(h.stepInterval):
+			}
+		}
+		if err := cur.ApplySteps(compass.Steps{step}); err != nil {
+			send("error", streamEvent{Error: err.Error()})
+			return
+		}
+		if !send("step", streamEvent{Step: step.String(), Compass: cur.String()}) {
+			return
+		}
+	}
+	send("done", streamEvent{Solution: solution.String()})
+}
@@ -0,0 +1,151 @@
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// newTestServer 创建测试用的服务
+func newTestServer(t *testing.T, stepInterval time.Duration) *httptest.Server {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	return httptest.NewServer(newHandler(logr.Discard(), solver, stepInterval))
+}
+
+// TestSolveStream 测试 GET /solve/stream
+func TestSolveStream(t *testing.T) {
+	server := newTestServer(t, 0)
+	defer server.Close()
+
+	cases := []struct {
+		compass        string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			compass:        "0+1,4-4,0+2/oi,om,mi",
+			expectedStatus: http.StatusOK,
+			expectedBody: "event: compass\ndata: {\"compass\":\"0+1,4-4,0+2/mi,oi,om\"}\n\n" +
+				"event: step\ndata: {\"step\":\"mi2\",\"compass\":\"0+1,2-4,4+2/mi,oi,om\"}\n\n" +
+				"event: step\ndata: {\"step\":\"oi4\",\"compass\":\"4+1,2-4,0+2/mi,oi,om\"}\n\n" +
+				"event: step\ndata: {\"step\":\"om2\",\"compass\":\"0+1,0-4,0+2/mi,oi,om\"}\n\n" +
+				"event: done\ndata: {\"solution\":\"mi2,oi4,om2\"}\n\n",
+		},
+		// 无解
+		{
+			compass:        "3+2,0+1,0+1/o",
+			expectedStatus: http.StatusOK,
+			expectedBody: "event: compass\ndata: {\"compass\":\"3+2,0+1,0+1/o\"}\n\n" +
+				"event: error\ndata: {\"error\":\"solve failed for 3+2,0+1,0+1/o: the compass has no solution: outer ring can only reach locations [1 3 5], which do not include the target location 0\"}\n\n",
+		},
+		// 无法解析
+		{
+			compass:        "foo",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range cases {
+		resp, err := http.Get(server.URL + "/solve/stream?compass=" + url.QueryEscape(tc.compass))
+		if err != nil {
+			t.Errorf("request error: %s", err)
+			continue
+		}
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Errorf("read body error: %s", err)
+			continue
+		}
+		if resp.StatusCode != tc.expectedStatus {
+			t.Errorf("unexpected status of %s: %d (expected: %d)", tc.compass, resp.StatusCode, tc.expectedStatus)
+			continue
+		}
+		if tc.expectedStatus == http.StatusOK && string(body) != tc.expectedBody {
+			t.Errorf("unexpected result of %s: %q (expected: %q)", tc.compass, body, tc.expectedBody)
+		}
+	}
+}
+
+// TestSolveStreamDisconnect 测试客户端断开时流式求解结束
+func TestSolveStreamDisconnect(t *testing.T) {
+	server := newTestServer(t, time.Hour)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/solve/stream?compass="+url.QueryEscape("0+1,4-4,0+2/oi,om,mi"), nil)
+	if err != nil {
+		t.Fatalf("new request error: %s", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	// 读到初始事件后断开
+	buf := make([]byte, 1)
+	if _, err := resp.Body.Read(buf); err != nil {
+		t.Fatalf("read body error: %s", err)
+	}
+	cancel()
+
+	// 处理器应当随请求上下文取消而结束，否则关闭服务会一直阻塞
+	done := make(chan struct{})
+	go func() {
+		server.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("stream not closed after client disconnected")
+	}
+}
+
+// TestSchema 测试 GET /schema
+func TestSchema(t *testing.T) {
+	server := newTestServer(t, 0)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/schema")
+	if err != nil {
+		t.Fatalf("request error: %s", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d (expected: %d)", resp.StatusCode, http.StatusOK)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/schema+json" {
+		t.Errorf("unexpected content type: %s (expected: application/schema+json)", contentType)
+	}
+	var schema struct {
+		Title string `json:"title"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&schema); err != nil {
+		t.Fatalf("decode schema error: %s", err)
+	}
+	if schema.Title != "Navigation Compass" {
+		t.Errorf("unexpected title: %#v (expected: \"Navigation Compass\")", schema.Title)
+	}
+
+	resp, err = http.Post(server.URL+"/schema", "application/json", nil)
+	if err != nil {
+		t.Fatalf("request error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status: %d (expected: %d)", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
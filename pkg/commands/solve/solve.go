@@ -1,44 +1,741 @@
 package solve
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/bombsimon/logrusr/v4"
-	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/commands/source"
+	"github.com/keybrl/hksr-compass/pkg/commands/timing"
 	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/compassimage"
+	"github.com/keybrl/hksr-compass/pkg/profile"
+	"github.com/keybrl/hksr-compass/pkg/store"
+	"github.com/keybrl/hksr-compass/pkg/terms"
 )
 
+var (
+	flagTraceFile string
+	flagCost      map[string]int
+	flagLimit     map[string]int
+	flagDecode    string
+	flagNotches   bool
+	flagOptimize  string
+	flagPretty    bool
+	flagFixed     string
+	flagTiming    bool
+	flagColor     string
+	flagNearest   bool
+	flagAvoid     []string
+	flagGIF       string
+	flagGIFDelay  time.Duration
+	flagGIFSize   int
+	flagAll       bool
+	flagMaxSols   int
+	flagNoRepeat  bool
+	flagTarget    string
+	flagProfile   string
+	flagTerms     string
+)
+
+const (
+	// 未给出参数时读取罗盘表达式的环境变量
+	envCompass = "COMPASS"
+	// --terms 不指定文件时使用内置的叫法
+	builtinTerms = "builtin"
+)
+
+// result 以 JSON 格式输出的求解结果
+type result struct {
+	Compass string `json:"compass"`
+	// 旋转速度超过一周时，保留旋转速度原始写法的罗盘，参见 compass.Compass.RawSpeedString
+	Entered  string `json:"entered,omitempty"`
+	Solution string `json:"solution"`
+	Moves    int    `json:"moves"`
+	// 解法按转动顺序输出时为空，参见 shareCode
+	ShareCode string `json:"share_code,omitempty"`
+	// 指定 --optimize rotation 时各圈转过的总角度（度）
+	Rotation int `json:"rotation,omitempty"`
+	// 指定 --terms 时以各圈分组的叫法展示的解法
+	Clicks string `json:"clicks,omitempty"`
+	// 罗盘已经解开（指定 --target 时为已经符合目标），解法为空
+	AlreadySolved bool `json:"already_solved,omitempty"`
+	// 指定 --target 时的目标，此时 solution 是转到该目标的步骤而不是解法，没有分享码
+	Target string `json:"target,omitempty"`
+	// 逐次转动的过程
+	Trace []traceStep `json:"trace"`
+	// 指定 --nearest 且罗盘无解时，解法转到的离目标状态最近的状态及其距离
+	Nearest  string `json:"nearest,omitempty"`
+	Distance int    `json:"distance,omitempty"`
+}
+
+// allResult 指定 --all 时以 JSON 格式输出的所有解法
+type allResult struct {
+	Compass   string   `json:"compass"`
+	Solutions []string `json:"solutions"`
+	// 解法总数，可能多于输出的解法数
+	Total int `json:"total"`
+}
+
+// nearestState 罗盘无解时，离目标状态最近的可到达的状态
+type nearestState struct {
+	compass  *compass.Compass
+	distance int
+}
+
+// traceStep 转动一次的过程，只包含位置发生变化的圈
+type traceStep struct {
+	Group  string     `json:"group"`
+	Outer  *ringMoved `json:"outer,omitempty"`
+	Middle *ringMoved `json:"middle,omitempty"`
+	Inner  *ringMoved `json:"inner,omitempty"`
+}
+
+// ringMoved 一个圈转动前后的位置
+type ringMoved struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
 // Cmd solve 命令
 var Cmd = &cobra.Command{
-	Use:   "solve COMPASS_EXPRESSION",
+	Use:   "solve [COMPASS_EXPRESSION]",
 	Short: "Solve a Navigation Compass.",
-	Args:  cobra.ExactArgs(1),
+	Long: `Solve a Navigation Compass.
+
+The compass expression is taken from the argument. If no argument is given, it
+is read from the first non-blank line of stdin when stdin is not a terminal, or
+from the COMPASS environment variable otherwise. "@NAME" refers to a compass
+saved by "save". With --profile, only the locations of the outer, middle and
+inner rings are given, e.g. "solve --profile example 3,0,5".`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("decode") {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		logger := logrusr.New(logrus.StandardLogger())
-		// 创建求解器
-		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+		logger := options.Logger()
+		// 解码分享码
+		if cmd.Flags().Changed("decode") {
+			solution, err := compass.DecodeSolution(flagDecode)
+			if err != nil {
+				logger.Error(err, "decode share code error")
+				return fmt.Errorf("decode share code error: %w", err)
+			}
+			fmt.Printf("Solution: %s\n", solution.String())
+			return nil
+		}
+
+		opts := compass.SolverOptions{
 			Logger: logger,
-		})
+		}
+		// 打开搜索过程跟踪文件
+		if flagTraceFile != "" {
+			traceFile, err := os.Create(flagTraceFile)
+			if err != nil {
+				logger.Error(err, "create trace file error")
+				return fmt.Errorf("create trace file error: %w", err)
+			}
+			defer traceFile.Close()
+			opts.Trace = traceFile
+		}
+		// 解析圈分组代价
+		if len(flagCost) > 0 {
+			groupCost, err := parseRingGroupMap(flagCost)
+			if err != nil {
+				logger.Error(err, "parse ring group costs error")
+				return fmt.Errorf("parse ring group costs error: %w", err)
+			}
+			opts.GroupCost = groupCost
+		}
+		// 解析圈分组最大转动次数
+		if len(flagLimit) > 0 {
+			groupLimits, err := parseRingGroupMap(flagLimit)
+			if err != nil {
+				logger.Error(err, "parse ring group limits error")
+				return fmt.Errorf("parse ring group limits error: %w", err)
+			}
+			opts.GroupLimits = groupLimits
+		}
+		// 创建求解器
+		var newSolver func(compass.SolverOptions) (compass.Solver, error)
+		switch flagOptimize {
+		case "length":
+			newSolver = compass.NewDefaultSolver
+		case "dials":
+			newSolver = compass.NewDialsSolver
+		case "balanced":
+			newSolver = compass.NewBalancedSolver
+		case "rotation":
+			newSolver = compass.NewRotationSolver
+		default:
+			return fmt.Errorf("unknown optimization objective: %s (must be one of [length dials balanced rotation])", flagOptimize)
+		}
+		if opts.GroupCost != nil && (flagOptimize == "dials" || flagOptimize == "balanced") {
+			return fmt.Errorf("--cost cannot be used with --optimize %s", flagOptimize)
+		}
+		// 解析需要避开的状态
+		if len(flagAvoid) > 0 && (flagOptimize == "dials" || flagOptimize == "balanced") {
+			return fmt.Errorf("--avoid cannot be used with --optimize %s", flagOptimize)
+		}
+		if len(flagAvoid) > 0 {
+			avoid, err := parseAvoid(flagAvoid)
+			if err != nil {
+				logger.Error(err, "parse states to avoid error")
+				return fmt.Errorf("parse states to avoid error: %w", err)
+			}
+			opts.Avoid = avoid
+		}
+		if flagNoRepeat && (flagOptimize == "dials" || flagOptimize == "balanced") {
+			return fmt.Errorf("--no-repeat cannot be used with --optimize %s", flagOptimize)
+		}
+		opts.NoConsecutiveRepeat = flagNoRepeat
+		// 最小转动角度求解器本身支持 --avoid 和 --no-repeat
+		if flagOptimize != "rotation" && (opts.GroupCost != nil || opts.Avoid != nil || opts.NoConsecutiveRepeat) {
+			newSolver = compass.NewMinCostSolver
+		}
+		// GIF 每帧的时长以 10ms 为单位，不足 10ms 时为 0
+		if flagGIF != "" && flagGIFDelay < 10*time.Millisecond {
+			return fmt.Errorf("--gif-delay must be at least 10ms, got %s", flagGIFDelay)
+		}
+		if flagAll && (opts.GroupCost != nil || opts.GroupLimits != nil || opts.Avoid != nil || opts.NoConsecutiveRepeat || flagNearest || flagOptimize != "length" || flagGIF != "") {
+			return fmt.Errorf("--all cannot be used with --cost, --limit, --avoid, --no-repeat, --nearest, --optimize or --gif")
+		}
+		// 离目标状态最近的状态不考虑这些限制，限制导致无解时会给出实际上能解开的状态
+		if flagNearest && (opts.GroupLimits != nil || opts.Avoid != nil || opts.NoConsecutiveRepeat) {
+			return fmt.Errorf("--nearest cannot be used with --limit, --avoid or --no-repeat")
+		}
+		if flagTarget != "" && (flagAll || opts.GroupCost != nil || opts.GroupLimits != nil || opts.Avoid != nil || opts.NoConsecutiveRepeat || flagNearest || flagOptimize != "length" || flagGIF != "") {
+			return fmt.Errorf("--target cannot be used with --all, --cost, --limit, --avoid, --no-repeat, --nearest, --optimize or --gif")
+		}
+		solver, err := newSolver(opts)
 		if err != nil {
 			logger.Error(err, "new solver for navigation compass error")
 			return fmt.Errorf("new solver for navigation compass error: %w", err)
 		}
-		// 解析输入罗盘
-		input, err := compass.ParseCompass(args[0])
+		// 以配置的旋转速度及圈分组构造罗盘
+		var profiles *profile.Profiles
+		if flagProfile != "" {
+			if flagNotches {
+				return fmt.Errorf("--profile cannot be used with --notches")
+			}
+			if profiles, err = profile.Default(); err != nil {
+				logger.Error(err, "open profiles error")
+				return fmt.Errorf("open profiles error: %w", err)
+			}
+		}
+		var stages timing.Stages
+		if flagTiming {
+			defer func() { timing.Fprint(os.Stderr, "", stages) }()
+		}
+		// 获取并解析输入罗盘
+		src := inputSource(args, stdinIfPiped(), func(expr string) (compass.Puzzle, error) {
+			return parsePuzzle(profiles, expr)
+		})
+		var compasses []*compass.Compass
+		err = timing.Measure(&stages.Parse, func() (err error) {
+			compasses, err = source.All(src)
+			return err
+		})
 		if err != nil {
 			logger.Error(err, "parse compass error")
 			return fmt.Errorf("parse compass error: %w", err)
 		}
-		// 求解罗盘
-		solution, err := solver.Solve(cmd.Context(), input)
+		if len(compasses) == 0 {
+			return errors.New("no compass expression given in the argument, stdin or the " + envCompass + " environment variable")
+		}
+		// 读取各圈分组的叫法
+		var t terms.Terms
+		switch flagTerms {
+		case "":
+		case builtinTerms:
+			t = terms.Default()
+		default:
+			if t, err = terms.Load(flagTerms); err != nil {
+				logger.Error(err, "load terms error")
+				return fmt.Errorf("load terms error: %w", err)
+			}
+		}
+		if flagGIF != "" && len(compasses) > 1 {
+			return fmt.Errorf("--gif supports only a single compass, got %d", len(compasses))
+		}
+		// 逐个求解谜题中的罗盘，多个罗盘的结果间以空行分隔；以 JSON 输出时多个罗盘的结果组成一个数组
+		jsonArray := len(compasses) > 1 && options.Format() == options.FormatJSON
+		var results []json.RawMessage
+		for i, c := range compasses {
+			var w io.Writer = os.Stdout
+			var buf bytes.Buffer
+			if jsonArray {
+				w = &buf
+			} else if i > 0 {
+				fmt.Println()
+			}
+			if err := solveCompass(cmd, solver, *c, t, &stages, w); err != nil {
+				if len(compasses) > 1 {
+					return fmt.Errorf("compass %d: %w", i+1, err)
+				}
+				return err
+			}
+			if jsonArray {
+				results = append(results, buf.Bytes())
+			}
+		}
+		if jsonArray {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(results)
+		}
+		return nil
+	},
+}
+
+// solveCompass 求解一个罗盘并将结果输出到 w ，各阶段的耗时累加到 stages
+// t 为 nil 时不以各圈分组的叫法展示解法
+func solveCompass(cmd *cobra.Command, solver compass.Solver, input compass.Compass, t terms.Terms, stages *timing.Stages, w io.Writer) error {
+	logger := options.Logger()
+	// 去掉包含无法转动的圈的圈分组
+	if flagFixed != "" {
+		ring, err := parseRingName(options.ExpandAliases(flagFixed))
 		if err != nil {
+			logger.Error(err, "parse fixed ring error")
+			return fmt.Errorf("parse fixed ring error: %w", err)
+		}
+		fixed, err := input.WithFixedRing(ring)
+		if err != nil {
+			logger.Error(err, "fix ring error")
+			return fmt.Errorf("fix ring error: %w", err)
+		}
+		input = *fixed
+	}
+	// 列出所有总转动次数最少的转动序列
+	if flagAll {
+		var solutions []compass.Steps
+		var total int
+		_ = timing.Measure(&stages.Solve, func() error {
+			solutions, total = input.AllSolutions(flagMaxSols), input.CountSolutions()
+			return nil
+		})
+		if total == 0 {
+			err := input.Solvability()
+			if err == nil {
+				err = compass.ErrUnsolvable
+			}
 			logger.Error(err, "solve navigation compass error")
-			return fmt.Errorf("solve navigation compass error: %w", err)
+			return compass.WrapSolveError(input, err)
 		}
-		fmt.Printf("Compass:  %s\n", input.String())
-		fmt.Printf("Solution: %s\n", solution.String())
+		return timing.Measure(&stages.Format, func() error {
+			return printAllSolutions(w, input, solutions, total)
+		})
+	}
+	// 求解罗盘，指定了目标时转到符合目标的状态
+	var solution compass.Steps
+	var err error
+	if flagTarget != "" {
+		var target [3]*int
+		if target, err = parseTarget(flagTarget); err != nil {
+			logger.Error(err, "parse target error")
+			return fmt.Errorf("parse target error: %w", err)
+		}
+		err = timing.Measure(&stages.Solve, func() (err error) {
+			solution, err = input.SolvePattern(target)
+			return err
+		})
+	} else {
+		err = timing.Measure(&stages.Solve, func() (err error) {
+			solution, err = solver.Solve(cmd.Context(), input)
+			return err
+		})
+	}
+	// 无解时转到离目标状态最近的状态
+	var nearest *nearestState
+	if errors.Is(err, compass.ErrUnsolvable) && flagNearest {
+		logger.V(1).Info(fmt.Sprintf("compass is unsolvable, look for the nearest state: %s", err))
+		nearest = &nearestState{}
+		nearest.compass, solution, nearest.distance = input.Nearest()
+		err = nil
+	}
+	// 无解时提示可能漏记的圈分组
+	if errors.Is(err, compass.ErrUnsolvable) {
+		if rg, ok := input.SolvableWithExtraGroup(); ok {
+			err = fmt.Errorf("%w (the compass would be solvable with ring group %s, was it missed?)", err, rg.ShortName())
+		}
+	}
+	if err != nil {
+		logger.Error(err, "solve navigation compass error")
+		return compass.WrapSolveError(input, err)
+	}
+	// 输出逐步转动的 GIF 动画
+	if flagGIF != "" {
+		if err := writeGIF(flagGIF, input, solution); err != nil {
+			logger.Error(err, "write gif error")
+			return fmt.Errorf("write gif error: %w", err)
+		}
+	}
+	return timing.Measure(&stages.Format, func() error {
+		return printResult(w, input, solution, nearest, t)
+	})
+}
+
+// writeGIF 将按解法逐次转动罗盘的过程绘制为 GIF 动画写入文件
+func writeGIF(path string, input compass.Compass, solution compass.Steps) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := compassimage.EncodeGIF(f, &input, solution, flagGIFSize, int(flagGIFDelay/(10*time.Millisecond))); err != nil {
+		_ = f.Close()
+		return err
+	}
+	// 关闭失败时文件可能没有完整写入
+	return f.Close()
+}
+
+// printResult 按全局参数指定的格式将求解结果输出到 w
+// nearest 非空时， solution 是转到离目标状态最近的状态的步骤
+func printResult(w io.Writer, input compass.Compass, solution compass.Steps, nearest *nearestState, t terms.Terms) error {
+	clicks := ""
+	if t != nil {
+		clicks = terms.FormatSolution(solution, t)
+	}
+	// 旋转速度超过一周时同时展示输入的写法，便于核对
+	entered := ""
+	if raw := input.RawSpeedString(); raw != input.String() {
+		entered = raw
+	}
+	// 指定了目标时输出的是转到目标的步骤，已经符合目标时即无需转动
+	target := ""
+	alreadySolved := input.IsSolved()
+	if flagTarget != "" {
+		pattern, err := parseTarget(flagTarget)
+		if err != nil {
+			return err
+		}
+		target = compass.PatternString(pattern)
+		alreadySolved = input.MatchesPattern(pattern)
+	}
+	switch options.Format() {
+	case options.FormatJSON:
+		trace, err := traceSolution(input, solution)
+		if err != nil {
+			options.Logger().Error(err, "trace solution error")
+			return fmt.Errorf("trace solution error: %w", err)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		ret := result{
+			Compass:   input.String(),
+			Entered:   entered,
+			Solution:  solutionString(solution),
+			Moves:     solution.TotalCount(),
+			ShareCode: shareCode(solution),
+			Clicks:    clicks,
+			Target:    target,
+			Trace:     trace,
+
+			AlreadySolved: alreadySolved,
+		}
+		if flagOptimize == "rotation" {
+			ret.Rotation = 60 * input.Rotation(solution)
+		}
+		if nearest != nil {
+			ret.Nearest = nearest.compass.String()
+			ret.Distance = nearest.distance
+		}
+		return encoder.Encode(ret)
+	case options.FormatEmoji:
+		fmt.Fprintln(w, input.EmojiString())
+		if nearest != nil {
+			fmt.Fprintf(w, "🏁 %s (%d)\n", nearest.compass.String(), nearest.distance)
+		}
+		if target != "" {
+			fmt.Fprintf(w, "🎯 %s: %s\n", target, solutionString(solution))
+			return nil
+		}
+		fmt.Fprintf(w, "🧭 %s\n", solutionString(solution))
 		return nil
-	},
+	}
+	if flagPretty {
+		color, err := useColor(flagColor, os.Stdout, os.Getenv("NO_COLOR"))
+		if err != nil {
+			return err
+		}
+		// 有叫法的圈以叫法作为字符画的标签
+		labels := compass.RenderLabels{
+			Outer:  t[compass.OuterRingGroup],
+			Middle: t[compass.MiddleRingGroup],
+			Inner:  t[compass.InnerRingGroup],
+		}
+		fmt.Fprintln(w, input.RenderWith(labels, color))
+	}
+	fmt.Fprintf(w, "Compass:  %s\n", input.String())
+	if entered != "" {
+		fmt.Fprintf(w, "Entered:  %s\n", entered)
+	}
+	if nearest != nil {
+		fmt.Fprintf(w, "Nearest:  %s (unsolvable, distance %d from the target)\n", nearest.compass.String(), nearest.distance)
+	}
+	switch {
+	case target != "" && alreadySolved:
+		fmt.Fprintf(w, "Steps:    (already at the target %s)\n", target)
+	case target != "":
+		fmt.Fprintf(w, "Steps:    %s (to the target %s)\n", solutionString(solution), target)
+	case alreadySolved:
+		fmt.Fprintln(w, "Solution: (already solved)")
+	default:
+		fmt.Fprintf(w, "Solution: %s\n", solutionString(solution))
+	}
+	if clicks != "" {
+		fmt.Fprintf(w, "Clicks:   %s\n", clicks)
+	}
+	if flagOptimize == "rotation" {
+		fmt.Fprintf(w, "Rotation: %d° in total\n", 60*input.Rotation(solution))
+	}
+	if code := shareCode(solution); code != "" {
+		fmt.Fprintf(w, "Share code: %s\n", code)
+	}
+	return nil
+}
+
+// printAllSolutions 按全局参数指定的格式将所有解法输出到 w ， total 为解法总数
+func printAllSolutions(w io.Writer, input compass.Compass, solutions []compass.Steps, total int) error {
+	strs := make([]string, len(solutions))
+	for i, solution := range solutions {
+		strs[i] = solution.OrderedString()
+	}
+	if options.Format() == options.FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(allResult{Compass: input.String(), Solutions: strs, Total: total})
+	}
+	fmt.Fprintf(w, "Compass:  %s\n", input.String())
+	fmt.Fprintf(w, "Solutions:\n")
+	for _, str := range strs {
+		fmt.Fprintf(w, "  %s\n", str)
+	}
+	fmt.Fprintf(w, "showing %d of %d solutions\n", len(strs), total)
+	return nil
+}
+
+func init() {
+	Cmd.Flags().BoolVar(&flagAll, "all", false, "list every order of clicks with the minimal moves instead of a single solution")
+	Cmd.Flags().IntVar(&flagMaxSols, "max-solutions", 20, "maximum number of solutions listed by --all (0 for no limit)")
+	Cmd.Flags().StringVar(&flagOptimize, "optimize", "length", "optimization objective of the solution, one of [length dials balanced rotation]")
+	Cmd.Flags().StringToIntVar(&flagCost, "cost", nil, "solve with the minimal total cost instead of the minimal moves, using the given cost of ring groups, e.g. \"om=2,i=3\" (others cost 1)")
+	Cmd.Flags().StringArrayVar(&flagAvoid, "avoid", nil, "never pass through the state with the given locations of the outer, middle and inner rings, e.g. \"5,5,5\" (can be repeated), the steps of the solution are then in order")
+	Cmd.Flags().BoolVar(&flagNoRepeat, "no-repeat", false, "never rotate the same ring group twice in a row, the steps of the solution are then in order")
+	Cmd.Flags().StringVar(&flagTarget, "target", "", "rotate to the state with the given locations of the outer, middle and inner rings instead of solving, \"_\" for any location, e.g. \"0,_,0\"")
+	Cmd.Flags().StringVar(&flagFixed, "fixed", "", "solve without rotating the given ring, one of [outer middle inner]")
+	Cmd.Flags().StringVar(&flagGIF, "gif", "", "also write the step-by-step rotation of the solution to the file as an animated GIF")
+	Cmd.Flags().DurationVar(&flagGIFDelay, "gif-delay", 500*time.Millisecond, "delay between frames of --gif, at least 10ms (rounded down to a multiple of 10ms)")
+	Cmd.Flags().IntVar(&flagGIFSize, "gif-size", 256, "width and height in pixels of --gif")
+	Cmd.Flags().BoolVar(&flagNearest, "nearest", false, "if the compass is unsolvable, output the steps to the reachable state nearest to the target instead of an error")
+	Cmd.Flags().BoolVar(&flagPretty, "pretty", false, "also render the compass as ASCII art (labeled with the names of --terms if given)")
+	Cmd.Flags().StringVar(&flagColor, "color", "auto", "colorize the ASCII art of --pretty, one of [auto always never] (auto colorizes when stdout is a terminal and NO_COLOR is not set)")
+	Cmd.Flags().StringVar(&flagProfile, "profile", "", "use the speeds and ring groups of the named profile, the argument is then the locations of the outer, middle and inner rings, e.g. \"3,0,5\"")
+	Cmd.Flags().StringVar(&flagTerms, "terms", "", "also show the solution with the names of ring groups in the JSON file mapping ring groups to names, e.g. {\"om\": \"outer and middle\"} (ring groups not in the file use the short names), or \""+builtinTerms+"\" for the built-in Chinese names")
+	Cmd.Flags().BoolVar(&flagNotches, "notches", false, "speeds in the compass expression are written as direction and notches, e.g. \"0cw1,4ccw4,0cw2/oi,om,mi\"")
+	Cmd.Flags().StringVar(&flagDecode, "decode", "", "decode the share code of a solution instead of solving a compass")
+	Cmd.Flags().StringToIntVar(&flagLimit, "limit", nil, "maximum moves of ring groups, e.g. \"om=5,i=3\" (others are unlimited)")
+	Cmd.Flags().BoolVar(&flagTiming, "timing", false, "print the time spent parsing, solving and formatting to stderr")
+	Cmd.Flags().StringVar(&flagTraceFile, "trace-file", "", "write the explored search graph to the file in Graphviz DOT format (for debugging)")
+}
+
+// stdinIfPiped 返回标准输入，标准输入是终端时返回 nil
+func stdinIfPiped() io.Reader {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return nil
+	}
+	return os.Stdin
+}
+
+// inputSource 返回输入罗盘的来源，以 parse 解析谜题表达式
+// 优先使用参数，其次是 stdin 中第一个非空行（ stdin 为 nil 时跳过），最后是环境变量 COMPASS 的值
+func inputSource(args []string, stdin io.Reader, parse func(string) (compass.Puzzle, error)) source.CompassSource {
+	arg := ""
+	if len(args) > 0 {
+		arg = args[0]
+	}
+	return source.First(
+		source.Puzzle(arg, parse),
+		source.FirstLine(stdin, parse),
+		source.Env(envCompass, parse),
+	)
+}
+
+// parsePuzzle 解析输入的谜题表达式， profiles 非空时先以 --profile 指定的配置构造罗盘表达式，其余按 --notches 解析
+// 形如 "@NAME" 的罗盘直接使用已保存的罗盘，不按 --notches 等解析
+func parsePuzzle(profiles *profile.Profiles, expr string) (compass.Puzzle, error) {
+	if profiles != nil {
+		var err error
+		if expr, err = profiles.Expand(flagProfile, expr); err != nil {
+			return compass.Puzzle{}, fmt.Errorf("apply profile error: %w", err)
+		}
+	}
+	parseCompass := compass.ParseCompass
+	if flagNotches {
+		parseCompass = compass.ParseCompassNotches
+	}
+	parse := func(expr string) (compass.Compass, error) {
+		return parseCompass(options.ExpandAliases(expr))
+	}
+	if strings.Contains(expr, "@") {
+		s, err := store.Default()
+		if err != nil {
+			return compass.Puzzle{}, fmt.Errorf("open store error: %w", err)
+		}
+		parse = s.Parser(parse)
+	}
+	return compass.ParsePuzzleFunc(expr, parse)
+}
+
+// useColor 判断是否彩色输出， mode 为 "auto" 时仅在 out 是终端且 noColor （环境变量 NO_COLOR 的值）为空时彩色输出
+func useColor(mode string, out *os.File, noColor string) (bool, error) {
+	switch mode {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		if noColor != "" {
+			return false, nil
+		}
+		info, err := out.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	}
+	return false, fmt.Errorf("unknown color mode: %s (must be one of [auto always never])", mode)
+}
+
+// inOrder 判断解法是否需要按转动顺序输出，指定了需要避开的状态或禁止连续转动相同的圈分组时转动顺序会影响结果
+func inOrder() bool {
+	return len(flagAvoid) > 0 || flagNoRepeat
+}
+
+// solutionString 返回输出的解法字符串表示，需要按转动顺序输出时保持转动顺序，参见 inOrder
+func solutionString(solution compass.Steps) string {
+	if inOrder() {
+		return solution.OrderedString()
+	}
+	return solution.String()
+}
+
+// shareCode 返回解法的分享码，需要按转动顺序输出或指定了目标时返回空字符串
+// 分享码只记录各圈分组的转动次数，解码后的转动顺序可能经过需要避开的状态，或连续转动相同的圈分组；
+// 转到目标的步骤不是解法，解码后会被当作解法
+func shareCode(solution compass.Steps) string {
+	if inOrder() || flagTarget != "" {
+		return ""
+	}
+	return compass.EncodeSolution(solution)
+}
+
+// parseTarget 解析求解的目标，即外圈、中圈、内圈的位置，以 , 分隔，位置不限的圈为 _ ，比如 "0,_,0"
+func parseTarget(str string) ([3]*int, error) {
+	var target [3]*int
+	parts := strings.Split(str, ",")
+	if len(parts) != 3 {
+		return target, fmt.Errorf("%w: invalid target \"%s\" (expected locations of the outer, middle and inner rings or \"_\" for any, e.g. \"0,_,0\")", compass.ErrParseFormat, str)
+	}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "_" {
+			continue
+		}
+		loc, err := strconv.Atoi(part)
+		if err != nil || loc < 0 || loc > 5 {
+			return target, fmt.Errorf("%w: invalid location \"%s\" in target \"%s\" (must be 0-5 or \"_\")", compass.ErrParseFormat, part, str)
+		}
+		target[i] = &loc
+	}
+	return target, nil
+}
+
+// parseAvoid 解析需要避开的状态，每个状态为外圈、中圈、内圈的位置，以 , 分隔，比如 "5,5,5"
+func parseAvoid(states []string) (func(*compass.Compass) bool, error) {
+	avoid := map[int]bool{}
+	for _, state := range states {
+		parts := strings.Split(state, ",")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("%w: invalid state \"%s\" (expected locations of the outer, middle and inner rings, e.g. \"5,5,5\")", compass.ErrParseFormat, state)
+		}
+		var locs [3]int
+		for i, part := range parts {
+			loc, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || loc < 0 || loc > 5 {
+				return nil, fmt.Errorf("%w: invalid location \"%s\" in state \"%s\" (must be 0-5)", compass.ErrParseFormat, part, state)
+			}
+			locs[i] = loc
+		}
+		avoid[locs[0]*36+locs[1]*6+locs[2]] = true
+	}
+	return func(c *compass.Compass) bool {
+		return avoid[c.Hash()]
+	}, nil
+}
+
+// parseRingGroupMap 将以圈分组简写名为键的映射转为以圈分组为键的映射
+func parseRingGroupMap(m map[string]int) (map[compass.RingGroup]int, error) {
+	ret := make(map[compass.RingGroup]int, len(m))
+	for k, v := range m {
+		rg, err := compass.ParseRingGroup(options.ExpandAliases(k))
+		if err != nil {
+			return nil, err
+		}
+		ret[rg] = v
+	}
+	return ret, nil
+}
+
+// parseRingName 解析单个圈的名称，比如 "outer" 或其简写 "o"
+func parseRingName(name string) (compass.RingGroup, error) {
+	for _, ring := range []compass.RingGroup{compass.OuterRingGroup, compass.MiddleRingGroup, compass.InnerRingGroup} {
+		if strings.EqualFold(name, ring.Name()) || strings.EqualFold(name, ring.ShortName()) {
+			return ring, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown ring: %s (must be one of [outer middle inner])", compass.ErrParseFormat, name)
+}
+
+// traceSolution 按解法逐次转动罗盘，返回每次转动前后各圈位置的变化
+func traceSolution(input compass.Compass, solution compass.Steps) ([]traceStep, error) {
+	cur := input.Clone()
+	ret := make([]traceStep, 0, solution.TotalCount())
+	for _, step := range solution {
+		// 圈分组名，即转动一次的步骤去掉转动次数
+		click := step
+		click.Count = 1
+		group := strings.TrimSuffix(click.String(), "1")
+
+		for i := 0; i < step.Count; i++ {
+			before := *cur
+			if err := cur.ApplySteps(compass.Steps{click}); err != nil {
+				return nil, err
+			}
+			t := traceStep{Group: group}
+			for _, r := range []struct {
+				from, to compass.Ring
+				moved    **ringMoved
+			}{
+				{from: before.OuterRing, to: cur.OuterRing, moved: &t.Outer},
+				{from: before.MiddleRing, to: cur.MiddleRing, moved: &t.Middle},
+				{from: before.InnerRing, to: cur.InnerRing, moved: &t.Inner},
+			} {
+				if r.from.Location != r.to.Location {
+					*r.moved = &ringMoved{From: r.from.Location, To: r.to.Location}
+				}
+			}
+			ret = append(ret, t)
+		}
+	}
+	return ret, nil
 }
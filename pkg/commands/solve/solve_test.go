@@ -0,0 +1,300 @@
+package solve
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/commands/source"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestTraceSolution 测试 traceSolution
+func TestTraceSolution(t *testing.T) {
+	input, err := compass.ParseCompass("3+1,4-4,0+2/om,i,(om+i)")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	solution, err := compass.ParseSteps("om3,(i+om)1")
+	if err != nil {
+		t.Fatalf("parse steps error: %s", err)
+	}
+
+	ret, err := traceSolution(input, solution)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	data, err := json.Marshal(ret)
+	if err != nil {
+		t.Fatalf("marshal error: %s", err)
+	}
+	expectedRet := `[` +
+		`{"group":"om","outer":{"from":3,"to":4},"middle":{"from":4,"to":0}},` +
+		`{"group":"om","outer":{"from":4,"to":5},"middle":{"from":0,"to":2}},` +
+		`{"group":"om","outer":{"from":5,"to":0},"middle":{"from":2,"to":4}},` +
+		`{"group":"(i+om)","outer":{"from":0,"to":1},"middle":{"from":4,"to":0},"inner":{"from":0,"to":2}}` +
+		`]`
+	if string(data) != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", data, expectedRet)
+	}
+}
+
+// TestInputSource 测试 inputSource 的优先级：参数 > stdin > 环境变量
+func TestInputSource(t *testing.T) {
+	cases := []struct {
+		args     []string
+		stdin    io.Reader
+		env      string
+		expected string
+	}{
+		{args: []string{"0+1,4-4,0+2/oi,om,mi"}, stdin: strings.NewReader("3+1,0-2,5+0/o,mi\n"), env: "1+1,0+1,0+1/o", expected: "0+1,4-4,0+2/oi,om,mi"},
+		{stdin: strings.NewReader("\n  3+1,0-2,5+0/o,mi \n1+1,0+1,0+1/o\n"), env: "1+1,0+1,0+1/o", expected: "3+1,0-2,5+0/o,mi"},
+		{stdin: strings.NewReader(""), env: " 1+1,0+1,0+1/o ", expected: "1+1,0+1,0+1/o"},
+		{env: "1+1,0+1,0+1/o", expected: "1+1,0+1,0+1/o"},
+		{stdin: strings.NewReader("\n"), expected: ""},
+	}
+	for _, c := range cases {
+		t.Setenv(envCompass, c.env)
+		ret, err := source.All(inputSource(c.args, c.stdin, compass.ParsePuzzle))
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+		if c.expected == "" {
+			if len(ret) != 0 {
+				t.Errorf("expected no compass, got: %v", ret)
+			}
+			continue
+		}
+		expected, err := compass.ParseCompass(c.expected)
+		if err != nil {
+			t.Fatalf("parse compass %s error: %s", c.expected, err)
+		}
+		if len(ret) != 1 || !reflect.DeepEqual(*ret[0], expected) {
+			t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+		}
+	}
+	// 参数中的罗盘无法解析时不再读取 stdin 和环境变量
+	t.Setenv(envCompass, "1+1,0+1,0+1/o")
+	if _, err := source.All(inputSource([]string{"bad"}, nil, compass.ParsePuzzle)); err == nil {
+		t.Errorf("expected error with bad argument")
+	}
+}
+
+// TestUseColor 测试 useColor
+func TestUseColor(t *testing.T) {
+	f, err := os.CreateTemp(t.TempDir(), "out")
+	if err != nil {
+		t.Fatalf("create temp file error: %s", err)
+	}
+	defer f.Close()
+	for _, c := range []struct {
+		mode     string
+		noColor  string
+		expected bool
+	}{
+		{mode: "always", noColor: "1", expected: true},
+		{mode: "never", expected: false},
+		// 普通文件不是终端
+		{mode: "auto", expected: false},
+		{mode: "auto", noColor: "1", expected: false},
+	} {
+		ret, err := useColor(c.mode, f, c.noColor)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			continue
+		}
+		if ret != c.expected {
+			t.Errorf("unexpected result of %s: %t (expected: %t)", c.mode, ret, c.expected)
+		}
+	}
+	if _, err := useColor("rainbow", f, ""); err == nil {
+		t.Errorf("expected error with unknown color mode")
+	}
+}
+
+// TestParseAvoid 测试 parseAvoid
+func TestParseAvoid(t *testing.T) {
+	avoid, err := parseAvoid([]string{"5,5,5", " 3, 3 ,0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for expr, expected := range map[string]bool{
+		"5+1,5+1,5+1/o": true,
+		"3+1,3+1,0+1/o": true,
+		"3+1,3+1,1+1/o": false,
+	} {
+		c, err := compass.ParseCompass(expr)
+		if err != nil {
+			t.Fatalf("parse compass error: %s", err)
+		}
+		if ret := avoid(&c); ret != expected {
+			t.Errorf("unexpected result of %s: %t (expected: %t)", expr, ret, expected)
+		}
+	}
+	for _, state := range []string{"5,5", "5,5,6", "a,0,0"} {
+		if _, err := parseAvoid([]string{state}); !errors.Is(err, compass.ErrParseFormat) {
+			t.Errorf("unexpected error of %#v: %v (expected: %s)", state, err, compass.ErrParseFormat)
+		}
+	}
+}
+
+// TestParseTarget 测试 parseTarget
+func TestParseTarget(t *testing.T) {
+	target, err := parseTarget("0, _ ,5")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ret := compass.PatternString(target); ret != "0,_,5" {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, "0,_,5")
+	}
+	for _, str := range []string{"0,_", "0,_,6", "a,0,0", "*,0,0"} {
+		if _, err := parseTarget(str); !errors.Is(err, compass.ErrParseFormat) {
+			t.Errorf("unexpected error of %#v: %v (expected: %s)", str, err, compass.ErrParseFormat)
+		}
+	}
+}
+
+// TestShareCode 测试 shareCode ，解法需要按转动顺序输出时没有分享码
+func TestShareCode(t *testing.T) {
+	solution, err := compass.ParseSteps("om1,o1,om2,m1,om1")
+	if err != nil {
+		t.Fatalf("parse steps error: %s", err)
+	}
+	if ret, expected := shareCode(solution), compass.EncodeSolution(solution); ret != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expected)
+	}
+
+	flagAvoid = []string{"3,3,0"}
+	if ret := shareCode(solution); ret != "" {
+		t.Errorf("unexpected result with --avoid: %#v (expected: %#v)", ret, "")
+	}
+	flagAvoid = nil
+
+	flagNoRepeat = true
+	defer func() { flagNoRepeat = false }()
+	if ret := shareCode(solution); ret != "" {
+		t.Errorf("unexpected result with --no-repeat: %#v (expected: %#v)", ret, "")
+	}
+}
+
+// TestPrintResultAlreadySolved 测试 printResult ，只有罗盘已经解开时才输出 "(already solved)"
+func TestPrintResultAlreadySolved(t *testing.T) {
+	for _, tc := range []struct {
+		expr     string
+		target   string
+		expected bool
+	}{
+		{expr: "0+1,0-4,0+2/oi,om,mi", expected: true},
+		// 已经符合目标、但中圈不在目标位置
+		{expr: "0+1,4-4,0+2/oi,om,mi", target: "0,_,0", expected: false},
+	} {
+		input, err := compass.ParseCompass(tc.expr)
+		if err != nil {
+			t.Fatalf("parse compass error: %s", err)
+		}
+		var solution compass.Steps
+		if tc.target != "" {
+			target, err := parseTarget(tc.target)
+			if err != nil {
+				t.Fatalf("parse target error: %s", err)
+			}
+			if solution, err = input.SolvePattern(target); err != nil {
+				t.Fatalf("solve error: %s", err)
+			}
+		}
+		if len(solution) != 0 {
+			t.Fatalf("unexpected solution of %s: %s (expected to be empty)", tc.expr, solution.String())
+		}
+
+		var buf bytes.Buffer
+		flagTarget = tc.target
+		err = printResult(&buf, input, solution, nil, nil)
+		flagTarget = ""
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if ret := strings.Contains(buf.String(), "(already solved)"); ret != tc.expected {
+			t.Errorf("unexpected output of %s: %#v (expected already solved: %t)", tc.expr, buf.String(), tc.expected)
+		}
+	}
+}
+
+// TestPrintResultJSONAlreadyAtTarget 测试 printResult 以 JSON 输出时，指定了目标时 already_solved 表示已经符合目标
+func TestPrintResultJSONAlreadyAtTarget(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	options.AddFlags(fs)
+	if err := fs.Parse([]string{"--format", options.FormatJSON}); err != nil {
+		t.Fatalf("parse flags error: %s", err)
+	}
+	defer func() { _ = fs.Parse([]string{"--format", options.FormatText}) }()
+	input, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	defer func() { flagTarget = "" }()
+	for _, tc := range []struct {
+		target   string
+		expected bool
+	}{
+		{target: "0,_,0", expected: true},
+		{target: "2,_,2", expected: false},
+	} {
+		flagTarget = tc.target
+		target, err := parseTarget(tc.target)
+		if err != nil {
+			t.Fatalf("parse target error: %s", err)
+		}
+		solution, err := input.SolvePattern(target)
+		if err != nil {
+			t.Fatalf("solve error: %s", err)
+		}
+		var buf bytes.Buffer
+		if err := printResult(&buf, input, solution, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		var ret result
+		if err := json.Unmarshal(buf.Bytes(), &ret); err != nil {
+			t.Fatalf("unmarshal result error: %s", err)
+		}
+		if ret.AlreadySolved != tc.expected {
+			t.Errorf("unexpected already_solved with target %s: %t (expected: %t)", tc.target, ret.AlreadySolved, tc.expected)
+		}
+	}
+}
+
+// TestPrintResultTarget 测试 printResult ，指定了目标时输出的是转到目标的步骤，没有分享码
+func TestPrintResultTarget(t *testing.T) {
+	input, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	flagTarget = "0, 0,_"
+	defer func() { flagTarget = "" }()
+	target, err := parseTarget(flagTarget)
+	if err != nil {
+		t.Fatalf("parse target error: %s", err)
+	}
+	solution, err := input.SolvePattern(target)
+	if err != nil {
+		t.Fatalf("solve error: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printResult(&buf, input, solution, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := "Compass:  0+1,4-4,0+2/mi,oi,om\n" +
+		"Steps:    mi1 (to the target 0,0,_)\n"
+	if buf.String() != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", buf.String(), expected)
+	}
+}
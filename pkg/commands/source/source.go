@@ -0,0 +1,279 @@
+package source
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/clipboard"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// ErrRead 读取来源出错，比如读取文件失败，之后的 Next 返回 io.EOF
+var ErrRead = errors.New("read source error")
+
+// CompassSource 罗盘的输入来源，比如参数、标准输入、环境变量、文件或剪贴板
+// Next 依次返回来源中的罗盘，没有更多罗盘时返回 io.EOF 。
+// 返回其它错误时，除非错误包装了 context 的错误或 ErrRead ，只说明当前罗盘有误（比如无法解析），可以继续调用 Next
+type CompassSource interface {
+	Next() (*compass.Compass, error)
+}
+
+// All 读取来源中的所有罗盘，直到 io.EOF ，遇到其它错误时返回该错误
+func All(src CompassSource) ([]*compass.Compass, error) {
+	var ret []*compass.Compass
+	for {
+		c, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return ret, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+}
+
+// sliceSource 依次返回给定罗盘的来源
+type sliceSource struct {
+	compasses []*compass.Compass
+}
+
+// Slice 返回依次返回给定罗盘的来源，可以在测试中代替其它来源
+func Slice(compasses ...*compass.Compass) CompassSource {
+	return &sliceSource{compasses: compasses}
+}
+
+// Next 返回下一个罗盘
+func (s *sliceSource) Next() (*compass.Compass, error) {
+	if len(s.compasses) == 0 {
+		return nil, io.EOF
+	}
+	c := s.compasses[0]
+	s.compasses = s.compasses[1:]
+	return c, nil
+}
+
+// puzzleSource 依次返回谜题表达式中各罗盘的来源
+type puzzleSource struct {
+	expr  string
+	parse func(string) (compass.Puzzle, error)
+	// 解析后剩余的罗盘，解析前为 nil
+	rest CompassSource
+}
+
+// Puzzle 返回依次返回谜题表达式（以 ; 分隔的多个罗盘，参见 compass.ParsePuzzle ）中各罗盘的来源，比如参数中的表达式
+// 第一次调用 Next 时以 parse 解析，解析失败时返回错误，之后返回 io.EOF ；表达式为空白时没有罗盘
+func Puzzle(expr string, parse func(string) (compass.Puzzle, error)) CompassSource {
+	return &puzzleSource{expr: expr, parse: parse}
+}
+
+// Next 返回下一个罗盘
+func (s *puzzleSource) Next() (*compass.Compass, error) {
+	if s.rest == nil && strings.TrimSpace(s.expr) == "" {
+		s.rest = Slice()
+	}
+	if s.rest == nil {
+		puzzle, err := s.parse(s.expr)
+		if err != nil {
+			s.rest = Slice()
+			return nil, err
+		}
+		s.rest = Slice(puzzle.Compasses...)
+	}
+	return s.rest.Next()
+}
+
+// envSource 读取环境变量中的谜题表达式的来源
+type envSource struct {
+	name  string
+	parse func(string) (compass.Puzzle, error)
+	// 读取环境变量后即为 Puzzle 的来源
+	rest CompassSource
+}
+
+// Env 返回依次返回环境变量中的谜题表达式中各罗盘的来源，参见 Puzzle
+// 第一次调用 Next 时才读取环境变量，环境变量不存在或为空白时没有罗盘
+func Env(name string, parse func(string) (compass.Puzzle, error)) CompassSource {
+	return &envSource{name: name, parse: parse}
+}
+
+// Next 返回下一个罗盘
+func (s *envSource) Next() (*compass.Compass, error) {
+	if s.rest == nil {
+		s.rest = Puzzle(os.Getenv(s.name), s.parse)
+	}
+	return s.rest.Next()
+}
+
+// firstLineSource 读取第一个非空行中的谜题表达式的来源
+type firstLineSource struct {
+	r     io.Reader
+	parse func(string) (compass.Puzzle, error)
+	// 读取第一个非空行后即为 Puzzle 的来源
+	rest CompassSource
+}
+
+// FirstLine 返回依次返回 r 中第一个非空行的谜题表达式中各罗盘的来源，比如管道输入的标准输入，参见 Puzzle
+// 第一次调用 Next 时才读取 r ，其余的行不读取； r 为 nil 或没有非空行时没有罗盘，读取出错时返回包装了 ErrRead 的错误
+func FirstLine(r io.Reader, parse func(string) (compass.Puzzle, error)) CompassSource {
+	return &firstLineSource{r: r, parse: parse}
+}
+
+// Next 返回下一个罗盘
+func (s *firstLineSource) Next() (*compass.Compass, error) {
+	if s.rest != nil {
+		return s.rest.Next()
+	}
+	s.rest = Slice()
+	if s.r == nil {
+		return nil, io.EOF
+	}
+	scanner := bufio.NewScanner(s.r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			s.rest = Puzzle(line, s.parse)
+			return s.rest.Next()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRead, err)
+	}
+	return nil, io.EOF
+}
+
+// firstSource 使用第一个有罗盘的来源
+type firstSource struct {
+	srcs []CompassSource
+	// 已经选定的来源
+	cur CompassSource
+}
+
+// First 返回依次尝试各来源、使用第一个有罗盘（第一次调用 Next 不返回 io.EOF ）的来源的来源，
+// 比如依次尝试参数、标准输入、环境变量；之后的来源不会被读取，所有来源都没有罗盘时返回 io.EOF
+func First(srcs ...CompassSource) CompassSource {
+	return &firstSource{srcs: srcs}
+}
+
+// Next 返回下一个罗盘
+func (s *firstSource) Next() (*compass.Compass, error) {
+	if s.cur != nil {
+		return s.cur.Next()
+	}
+	for _, src := range s.srcs {
+		c, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+		s.cur = src
+		return c, err
+	}
+	s.cur = Slice()
+	return nil, io.EOF
+}
+
+// LinesSource 逐行读取罗盘表达式的来源，参见 Lines
+type LinesSource struct {
+	scanner *bufio.Scanner
+	parse   func(string) (compass.Compass, error)
+	lineNo  int
+	// 读取出错后不再继续读取
+	failed bool
+}
+
+// Lines 返回逐行读取 r 中罗盘表达式的来源，忽略空行及以 "#" 开头的行
+// 以 parse 解析各行，解析失败时返回包含行号的错误，可以继续读取之后的行；
+// 读取 r 出错时返回包装了 ErrRead 的错误，之后返回 io.EOF
+func Lines(r io.Reader, parse func(string) (compass.Compass, error)) *LinesSource {
+	return &LinesSource{scanner: bufio.NewScanner(r), parse: parse}
+}
+
+var _ CompassSource = &LinesSource{}
+
+// Line 返回上一次 Next 读取的行的行号（从 1 开始），即返回的罗盘或解析错误所在的行
+func (s *LinesSource) Line() int {
+	return s.lineNo
+}
+
+// Next 返回下一个罗盘
+func (s *LinesSource) Next() (*compass.Compass, error) {
+	if s.failed {
+		return nil, io.EOF
+	}
+	for s.scanner.Scan() {
+		s.lineNo++
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		c, err := s.parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", s.lineNo, err)
+		}
+		return &c, nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		s.failed = true
+		return nil, fmt.Errorf("%w: read line %d error: %s", ErrRead, s.lineNo+1, err)
+	}
+	return nil, io.EOF
+}
+
+// clipboardSource 轮询剪贴板的来源
+type clipboardSource struct {
+	ctx      context.Context
+	logger   logr.Logger
+	cb       clipboard.Clipboard
+	interval time.Duration
+	parse    func(string) (compass.Compass, error)
+	// 上一次读取的内容
+	last string
+	// 是否已读取过，第一次读取前不等待
+	polled bool
+}
+
+// Clipboard 返回轮询剪贴板的来源，每当剪贴板内容变为新的罗盘表达式时返回该罗盘
+// 以 parse 解析，不是罗盘表达式的内容及读取剪贴板的错误只记录日志并继续轮询，直到 ctx 被取消时返回 ctx 的错误
+func Clipboard(ctx context.Context, logger logr.Logger, cb clipboard.Clipboard, interval time.Duration, parse func(string) (compass.Compass, error)) CompassSource {
+	return &clipboardSource{ctx: ctx, logger: logger, cb: cb, interval: interval, parse: parse}
+}
+
+// Next 返回下一个罗盘
+func (s *clipboardSource) Next() (*compass.Compass, error) {
+	for {
+		if s.polled {
+			select {
+			case <-s.ctx.Done():
+				return nil, s.ctx.Err()
+			case <-time.After(s.interval):
+			}
+		}
+		s.polled = true
+
+		text, err := s.cb.ReadText(s.ctx)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return nil, s.ctx.Err()
+			}
+			s.logger.Error(err, "read clipboard error")
+			continue
+		}
+		if text = strings.TrimSpace(text); text == s.last {
+			continue
+		}
+		s.last = text
+		c, err := s.parse(text)
+		if err != nil {
+			s.logger.V(1).Info(fmt.Sprintf("ignore clipboard content: %s", err))
+			continue
+		}
+		return &c, nil
+	}
+}
@@ -0,0 +1,179 @@
+package source
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/clipboard/clipboardtest"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// compassStrings 把来源中的罗盘转为字符串表示，解析失败的罗盘为 "error"
+func compassStrings(t *testing.T, src CompassSource) []string {
+	var ret []string
+	for i := 0; i < 100; i++ {
+		c, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return ret
+		}
+		if err != nil {
+			ret = append(ret, "error")
+			continue
+		}
+		ret = append(ret, c.String())
+	}
+	t.Fatalf("source does not end: %v", ret)
+	return nil
+}
+
+// TestSlice 测试 Slice 及 All
+func TestSlice(t *testing.T) {
+	a, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	ret, err := All(Slice(&a, &a))
+	if err != nil || len(ret) != 2 || ret[0] != &a || ret[1] != &a {
+		t.Errorf("unexpected result: %v, %v (expected: [%s %s])", ret, err, &a, &a)
+	}
+	if ret, err := All(Slice()); err != nil || len(ret) != 0 {
+		t.Errorf("unexpected result: %v, %v (expected: [])", ret, err)
+	}
+}
+
+// TestPuzzle 测试 Puzzle
+func TestPuzzle(t *testing.T) {
+	ret := compassStrings(t, Puzzle("0+1,4-4,0+2/oi,om,mi;3+1,0-2,0+2/o", compass.ParsePuzzle))
+	if expected := []string{"0+1,4-4,0+2/mi,oi,om", "3+1,0-2,0+2/o"}; strings.Join(ret, ";") != strings.Join(expected, ";") {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+	// 解析失败时只返回一次错误
+	ret = compassStrings(t, Puzzle("0+1,4-4,0+2/oi,om,mi;foo", compass.ParsePuzzle))
+	if expected := []string{"error"}; strings.Join(ret, ";") != strings.Join(expected, ";") {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+	if _, err := All(Puzzle("foo", compass.ParsePuzzle)); !errors.Is(err, compass.ErrParseFormat) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, compass.ErrParseFormat)
+	}
+	// 空白的表达式没有罗盘
+	if ret := compassStrings(t, Puzzle("  ", compass.ParsePuzzle)); len(ret) != 0 {
+		t.Errorf("unexpected result: %v (expected: [])", ret)
+	}
+}
+
+// TestEnv 测试 Env
+func TestEnv(t *testing.T) {
+	src := Env("TEST_COMPASS", compass.ParsePuzzle)
+	// 第一次调用 Next 时才读取环境变量
+	t.Setenv("TEST_COMPASS", " 0+1,4-4,0+2/oi,om,mi ")
+	ret := compassStrings(t, src)
+	if expected := []string{"0+1,4-4,0+2/mi,oi,om"}; strings.Join(ret, ";") != strings.Join(expected, ";") {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+	t.Setenv("TEST_COMPASS", "")
+	if ret := compassStrings(t, Env("TEST_COMPASS", compass.ParsePuzzle)); len(ret) != 0 {
+		t.Errorf("unexpected result: %v (expected: [])", ret)
+	}
+}
+
+// errReader 读取时总是返回错误
+type errReader struct{}
+
+// Read 返回错误
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+// TestFirstLine 测试 FirstLine ，只使用第一个非空行
+func TestFirstLine(t *testing.T) {
+	ret := compassStrings(t, FirstLine(strings.NewReader("\n  0+1,4-4,0+2/oi,om,mi \n3+1,0-2,0+2/o\n"), compass.ParsePuzzle))
+	if expected := []string{"0+1,4-4,0+2/mi,oi,om"}; strings.Join(ret, ";") != strings.Join(expected, ";") {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+	for _, r := range []io.Reader{nil, strings.NewReader(""), strings.NewReader("\n \n")} {
+		if ret := compassStrings(t, FirstLine(r, compass.ParsePuzzle)); len(ret) != 0 {
+			t.Errorf("unexpected result: %v (expected: [])", ret)
+		}
+	}
+	if _, err := All(FirstLine(errReader{}, compass.ParsePuzzle)); !errors.Is(err, ErrRead) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrRead)
+	}
+}
+
+// TestFirst 测试 First ，使用第一个有罗盘的来源，解析失败也不再尝试之后的来源
+func TestFirst(t *testing.T) {
+	for _, c := range []struct {
+		srcs     []CompassSource
+		expected []string
+	}{
+		{
+			srcs:     []CompassSource{Puzzle("", compass.ParsePuzzle), Puzzle("0+1,4-4,0+2/oi,om,mi", compass.ParsePuzzle), Puzzle("3+1,0-2,0+2/o", compass.ParsePuzzle)},
+			expected: []string{"0+1,4-4,0+2/mi,oi,om"},
+		},
+		{
+			srcs:     []CompassSource{Puzzle("foo", compass.ParsePuzzle), Puzzle("3+1,0-2,0+2/o", compass.ParsePuzzle)},
+			expected: []string{"error"},
+		},
+		{
+			srcs:     []CompassSource{Puzzle("", compass.ParsePuzzle), FirstLine(nil, compass.ParsePuzzle)},
+			expected: nil,
+		},
+		{expected: nil},
+	} {
+		ret := compassStrings(t, First(c.srcs...))
+		if strings.Join(ret, ";") != strings.Join(c.expected, ";") {
+			t.Errorf("unexpected result: %v (expected: %v)", ret, c.expected)
+		}
+	}
+}
+
+// TestLines 测试 Lines ，解析失败的行不影响之后的行
+func TestLines(t *testing.T) {
+	input := "# comment\n0+1,4-4,0+2/oi,om,mi\n\nfoo\n 3+1,0-2,0+2/o \n"
+	ret := compassStrings(t, Lines(strings.NewReader(input), compass.ParseCompass))
+	if expected := []string{"0+1,4-4,0+2/mi,oi,om", "error", "3+1,0-2,0+2/o"}; strings.Join(ret, ";") != strings.Join(expected, ";") {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+	src := Lines(strings.NewReader(input), compass.ParseCompass)
+	_, _ = src.Next()
+	if _, err := src.Next(); err == nil || !strings.HasPrefix(err.Error(), "line 4: ") {
+		t.Errorf("unexpected error: %v (expected to start with \"line 4: \")", err)
+	}
+	if src.Line() != 4 {
+		t.Errorf("unexpected line: %d (expected: 4)", src.Line())
+	}
+	if _, err := All(Lines(errReader{}, compass.ParseCompass)); !errors.Is(err, ErrRead) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrRead)
+	}
+}
+
+// TestClipboard 测试 Clipboard ，只返回变为新罗盘表达式的内容
+func TestClipboard(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cb := &clipboardtest.Fake{
+		Texts:  []string{"0+1,4-4,0+2/oi,om,mi", "0+1,4-4,0+2/oi,om,mi\n", "hello", "5+1,0+1,0+1/o"},
+		Cancel: cancel,
+	}
+	src := Clipboard(ctx, logr.Discard(), cb, time.Millisecond, compass.ParseCompass)
+	var ret []string
+	for {
+		c, err := src.Next()
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("unexpected error: %v (expected: %s)", err, context.Canceled)
+			}
+			break
+		}
+		ret = append(ret, c.String())
+	}
+	if expected := []string{"0+1,4-4,0+2/mi,oi,om", "5+1,0+1,0+1/o"}; strings.Join(ret, ";") != strings.Join(expected, ";") {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+}
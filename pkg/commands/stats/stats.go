@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// Cmd stats 命令
+var Cmd = &cobra.Command{
+	Use:   "stats COMPASS_EXPRESSION",
+	Short: "Show statistics of a Navigation Compass.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 解析输入罗盘
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+
+		fmt.Printf("Compass:  %s\n", input.String())
+		// 各圈分组的位移
+		fmt.Printf("Group effects:\n")
+		effects := input.GroupEffects()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintf(w, "group\touter\tmiddle\tinner\t\n")
+		for _, rg := range input.Standardize().RingGroups {
+			effect := effects[rg]
+			fmt.Fprintf(w, "%s\t%+d\t%+d\t%+d\t\n", rg.ShortName(), effect[0], effect[1], effect[2])
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		// 张成相同位移的最少圈分组
+		var names []string
+		for _, rg := range input.GeneratingGroups() {
+			names = append(names, rg.ShortName())
+		}
+		fmt.Printf("Generating groups: %s\n", strings.Join(names, ","))
+		// 整体旋转的对称
+		fmt.Printf("Symmetry order: %d (global rotations of all rings that the moves can undo)\n", input.SymmetryOrder())
+		return nil
+	},
+}
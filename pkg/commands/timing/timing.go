@@ -0,0 +1,50 @@
+package timing
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// Stages 命令各阶段的耗时
+type Stages struct {
+	// 解析罗盘耗时
+	Parse time.Duration
+	// 求解罗盘耗时
+	Solve time.Duration
+	// 格式化输出耗时
+	Format time.Duration
+}
+
+// Add 累加另一组耗时
+func (s *Stages) Add(other Stages) {
+	s.Parse += other.Parse
+	s.Solve += other.Solve
+	s.Format += other.Format
+}
+
+// Total 返回各阶段的总耗时
+func (s Stages) Total() time.Duration {
+	return s.Parse + s.Solve + s.Format
+}
+
+// String 转为字符串表示，比如 "parse 12µs, solve 1.5ms, format 30µs, total 1.542ms"
+func (s Stages) String() string {
+	return fmt.Sprintf("parse %s, solve %s, format %s, total %s", s.Parse, s.Solve, s.Format, s.Total())
+}
+
+// Measure 执行 f 并将其耗时累加到 d
+func Measure(d *time.Duration, f func() error) error {
+	start := time.Now()
+	err := f()
+	*d += time.Since(start)
+	return err
+}
+
+// Fprint 将耗时以 "timing: ..." 的形式输出到 w ， label 非空时附加在 "timing" 之后
+func Fprint(w io.Writer, label string, s Stages) {
+	if label != "" {
+		label = " (" + label + ")"
+	}
+	fmt.Fprintf(w, "timing%s: %s\n", label, s.String())
+}
@@ -0,0 +1,42 @@
+package timing
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestStages 测试 Stages 的累加及字符串表示
+func TestStages(t *testing.T) {
+	var s Stages
+	s.Add(Stages{Parse: time.Millisecond, Solve: 2 * time.Millisecond, Format: 3 * time.Millisecond})
+	s.Add(Stages{Parse: time.Millisecond})
+	expected := "parse 2ms, solve 2ms, format 3ms, total 7ms"
+	if ret := s.String(); ret != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expected)
+	}
+
+	var buf bytes.Buffer
+	Fprint(&buf, "3 cases", s)
+	expected = "timing (3 cases): " + expected + "\n"
+	if ret := buf.String(); ret != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expected)
+	}
+}
+
+// TestMeasure 测试 Measure 函数
+func TestMeasure(t *testing.T) {
+	d := time.Second
+	errTest := errors.New("test")
+	err := Measure(&d, func() error {
+		time.Sleep(time.Millisecond)
+		return errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, errTest)
+	}
+	if d < time.Second+time.Millisecond {
+		t.Errorf("unexpected duration: %s (expected at least: %s)", d, time.Second+time.Millisecond)
+	}
+}
@@ -0,0 +1,171 @@
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/commands/source"
+	"github.com/keybrl/hksr-compass/pkg/commands/timing"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+const (
+	// 期望结果为无解
+	unsolvable = "unsolvable"
+)
+
+var (
+	flagTiming bool
+)
+
+// Cmd verify 命令
+var Cmd = &cobra.Command{
+	Use:   "verify FILE",
+	Short: "Verify the solver against a file of expected solutions.",
+	Long: `Verify the solver against a file of expected solutions.
+
+Each line of the file is in the format "COMPASS_EXPRESSION => EXPECTED_SOLUTION",
+where EXPECTED_SOLUTION is a solution like "mi2,oi4,om2", or "unsolvable" if the
+compass has no solution. Blank lines and lines starting with "#" are ignored.
+
+A case passes when the solver's solution has the same number of moves as the
+expected solution, the exact steps are not compared.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 创建求解器
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+		// 打开文件
+		f, err := os.Open(args[0])
+		if err != nil {
+			logger.Error(err, "open file error")
+			return fmt.Errorf("open file error: %w", err)
+		}
+		defer f.Close()
+
+		// 逐行校验
+		var stages timing.Stages
+		passed, failed, err := verifyAll(cmd.Context(), solver, f, os.Stdout, &stages)
+		if errors.Is(err, source.ErrRead) {
+			logger.Error(err, "read file error")
+			return fmt.Errorf("read file error: %w", err)
+		}
+		if err != nil {
+			return err
+		}
+
+		_ = timing.Measure(&stages.Format, func() error {
+			fmt.Printf("%d passed, %d failed\n", passed, failed)
+			return nil
+		})
+		if flagTiming {
+			timing.Fprint(os.Stderr, fmt.Sprintf("total of %d cases", passed+failed), stages)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d cases failed", failed, passed+failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.Flags().BoolVar(&flagTiming, "timing", false, "print the total time spent parsing, solving and formatting of all cases to stderr")
+}
+
+// verifyAll 逐行校验 r 中的用例，不通过的用例及原因输出到 w ，返回通过及不通过的用例数，解析及求解的耗时累加到 stages
+// 读取 r 出错时返回包装了 source.ErrRead 的错误， ctx 被取消时返回 ctx 的错误
+func verifyAll(ctx context.Context, solver compass.Solver, r io.Reader, w io.Writer, stages *timing.Stages) (passed, failed int, err error) {
+	// 当前行的期望结果，解析每一行时设置，与 Next 返回的罗盘对应
+	var expected string
+	src := source.Lines(r, func(line string) (compass.Compass, error) {
+		parts := strings.Split(line, "=>")
+		if len(parts) != 2 {
+			return compass.Compass{}, fmt.Errorf("invalid line: \"%s\" (expected \"COMPASS_EXPRESSION => EXPECTED_SOLUTION\")", line)
+		}
+		expected = strings.TrimSpace(parts[1])
+		c, err := compass.ParseCompass(options.ExpandAliases(parts[0]))
+		if err != nil {
+			return c, fmt.Errorf("parse compass error: %w", err)
+		}
+		return c, nil
+	})
+	fail := func(err error) {
+		_ = timing.Measure(&stages.Format, func() error {
+			fmt.Fprintf(w, "FAIL %s\n", err)
+			return nil
+		})
+		failed++
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return passed, failed, err
+		}
+		var input *compass.Compass
+		err := timing.Measure(&stages.Parse, func() (err error) {
+			input, err = src.Next()
+			return err
+		})
+		if errors.Is(err, io.EOF) {
+			return passed, failed, nil
+		}
+		if errors.Is(err, source.ErrRead) {
+			return passed, failed, err
+		}
+		// 解析错误已经包含行号
+		if err != nil {
+			fail(err)
+			continue
+		}
+		if err := verifyCompass(ctx, solver, *input, expected, stages); err != nil {
+			fail(fmt.Errorf("line %d: %w", src.Line(), err))
+			continue
+		}
+		passed++
+	}
+}
+
+// verifyCompass 校验一个用例， expectedStr 为期望的解法或 "unsolvable" ，不通过时返回原因，求解的耗时累加到 stages
+func verifyCompass(ctx context.Context, solver compass.Solver, input compass.Compass, expectedStr string, stages *timing.Stages) error {
+
+	var solution compass.Steps
+	err := timing.Measure(&stages.Solve, func() (err error) {
+		solution, err = solver.Solve(ctx, input)
+		return err
+	})
+	if expectedStr == unsolvable {
+		if err == nil {
+			return fmt.Errorf("%s: expected unsolvable, got %s (%d moves)", input.String(), solution.String(), solution.TotalCount())
+		}
+		return nil
+	}
+	expected, err2 := compass.ParseSteps(expectedStr)
+	if err2 != nil {
+		return fmt.Errorf("parse expected solution error: %w", err2)
+	}
+	if err != nil {
+		return fmt.Errorf("expected %s (%d moves), got error: %w", expected.String(), expected.TotalCount(), compass.WrapSolveError(input, err))
+	}
+	if solution.TotalCount() != expected.TotalCount() {
+		return fmt.Errorf(
+			"%s: expected %s (%d moves), got %s (%d moves)",
+			input.String(),
+			expected.String(), expected.TotalCount(),
+			solution.String(), solution.TotalCount(),
+		)
+	}
+	return nil
+}
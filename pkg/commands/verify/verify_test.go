@@ -0,0 +1,67 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/source"
+	"github.com/keybrl/hksr-compass/pkg/commands/timing"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// errReader 读取时总是返回错误
+type errReader struct{}
+
+func (errReader) Read(_ []byte) (int, error) {
+	return 0, errors.New("broken")
+}
+
+// TestVerifyAll 测试 verifyAll ，不通过的用例输出行号及原因
+func TestVerifyAll(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	input := strings.Join([]string{
+		"# comment",
+		"0+1,4-4,0+2/oi,om,mi => mi2,oi4,om2",
+		"",
+		"3+1,0-2,0+2/o => o1",
+		"0+1,4-4,0+2/oi => unsolvable",
+		"foo => o1",
+		"0+1,4-4,0+2/oi,om,mi",
+	}, "\n")
+	out := &bytes.Buffer{}
+	var stages timing.Stages
+	passed, failed, err := verifyAll(context.Background(), solver, strings.NewReader(input), out, &stages)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if passed != 2 || failed != 3 {
+		t.Errorf("unexpected result: %d passed, %d failed (expected: 2 passed, 3 failed)", passed, failed)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	expectedPrefixes := []string{
+		"FAIL line 4: 3+1,0-2,0+2/o: expected o1 (1 moves), got o3 (3 moves)",
+		"FAIL line 6: parse compass error: ",
+		"FAIL line 7: invalid line: ",
+	}
+	if len(lines) != len(expectedPrefixes) {
+		t.Fatalf("unexpected output: %#v (expected %d lines)", out.String(), len(expectedPrefixes))
+	}
+	for i, line := range lines {
+		if !strings.HasPrefix(line, expectedPrefixes[i]) {
+			t.Errorf("unexpected output line %d: %#v (expected to start with %#v)", i+1, line, expectedPrefixes[i])
+		}
+	}
+
+	// 读取出错
+	if _, _, err := verifyAll(context.Background(), solver, errReader{}, out, &stages); !errors.Is(err, source.ErrRead) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, source.ErrRead)
+	}
+}
@@ -0,0 +1,88 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/clipboard"
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/commands/source"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+var (
+	flagInterval time.Duration
+)
+
+// Cmd watch 命令
+var Cmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch the clipboard and solve every Navigation Compass copied.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 打开剪贴板
+		cb, err := clipboard.NewSystemClipboard()
+		if err != nil {
+			logger.Error(err, "open clipboard error")
+			return fmt.Errorf("open clipboard error: %w", err)
+		}
+		// 创建求解器
+		solver, err := compass.NewDefaultSolver(compass.SolverOptions{
+			Logger: logger,
+		})
+		if err != nil {
+			logger.Error(err, "new solver for navigation compass error")
+			return fmt.Errorf("new solver for navigation compass error: %w", err)
+		}
+
+		logger.Info("watching clipboard for compass expressions, press Ctrl-C to stop")
+		err = watch(cmd.Context(), logger, cb, solver, flagInterval, os.Stdout)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return err
+	},
+}
+
+func init() {
+	Cmd.Flags().DurationVar(&flagInterval, "interval", 500*time.Millisecond, "interval for polling the clipboard")
+}
+
+// watch 轮询剪贴板，每当剪贴板内容变为新的罗盘表达式时求解并输出，直到上下文被取消
+func watch(
+	ctx context.Context,
+	logger logr.Logger,
+	cb clipboard.Clipboard,
+	solver compass.Solver,
+	interval time.Duration,
+	out io.Writer,
+) error {
+	src := source.Clipboard(ctx, logger, cb, interval, func(text string) (compass.Compass, error) {
+		return compass.ParseCompass(options.ExpandAliases(text))
+	})
+	for {
+		input, err := src.Next()
+		if err != nil {
+			return err
+		}
+		solveCompass(ctx, solver, input, out)
+	}
+}
+
+// solveCompass 求解剪贴板中的罗盘并输出
+func solveCompass(ctx context.Context, solver compass.Solver, input *compass.Compass, out io.Writer) {
+	solution, err := solver.Solve(ctx, *input)
+	if err != nil {
+		fmt.Fprintf(out, "Compass:  %s\nError:    %s\n", input.String(), err)
+		return
+	}
+	fmt.Fprintf(out, "Compass:  %s\nSolution: %s\n", input.String(), solution.String())
+}
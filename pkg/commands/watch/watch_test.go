@@ -0,0 +1,48 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/clipboard/clipboardtest"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestWatch 测试 watch
+func TestWatch(t *testing.T) {
+	solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cb := &clipboardtest.Fake{
+		Texts: []string{
+			"0+1,4-4,0+2/oi,om,mi",
+			// 内容没有变化，不重复求解
+			"0+1,4-4,0+2/oi,om,mi\n",
+			// 不是罗盘表达式，忽略
+			"hello",
+			"5+1,0+1,0+1/o",
+		},
+		Cancel: cancel,
+	}
+	out := &bytes.Buffer{}
+	err = watch(ctx, logr.Discard(), cb, solver, time.Millisecond, out)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, context.Canceled)
+	}
+
+	expectedRet := "Compass:  0+1,4-4,0+2/mi,oi,om\nSolution: mi2,oi4,om2\n" +
+		"Compass:  5+1,0+1,0+1/o\nSolution: o1\n"
+	if out.String() != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", out.String(), expectedRet)
+	}
+}
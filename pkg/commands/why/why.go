@@ -0,0 +1,167 @@
+package why
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/keybrl/hksr-compass/pkg/commands/options"
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// ringNames 外圈、中圈、内圈的名称
+var ringNames = [3]string{"outer", "middle", "inner"}
+
+// Cmd why 命令
+var Cmd = &cobra.Command{
+	Use:   "why COMPASS_EXPRESSION [SOLUTION]",
+	Short: "Explain the modular arithmetic behind the solution of a Navigation Compass.",
+	Long: `Explain the modular arithmetic behind the solution of a Navigation Compass.
+
+Prints how far each click moves each ring, how far each ring has to move to
+reach the target, and how the click counts of the solution add up to exactly
+that modulo 6 (a full turn). If SOLUTION is omitted, the compass is solved first.`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := options.Logger()
+		// 解析输入罗盘
+		input, err := compass.ParseCompass(options.ExpandAliases(args[0]))
+		if err != nil {
+			logger.Error(err, "parse compass error")
+			return fmt.Errorf("parse compass error: %w", err)
+		}
+
+		// 获取解法
+		var solution compass.Steps
+		if len(args) > 1 {
+			if solution, err = compass.ParseSteps(options.ExpandAliases(args[1])); err != nil {
+				logger.Error(err, "parse solution error")
+				return fmt.Errorf("parse solution error: %w", err)
+			}
+		} else {
+			solver, err := compass.NewDefaultSolver(compass.SolverOptions{Logger: logger})
+			if err != nil {
+				logger.Error(err, "new solver for navigation compass error")
+				return fmt.Errorf("new solver for navigation compass error: %w", err)
+			}
+			if solution, err = solver.Solve(cmd.Context(), input); err != nil {
+				logger.Error(err, "solve navigation compass error")
+				return fmt.Errorf("solve navigation compass error: %w", err)
+			}
+		}
+
+		explanation, err := explain(input, solution)
+		if err != nil {
+			logger.Error(err, "explain solution error")
+			return fmt.Errorf("explain solution error: %w", err)
+		}
+		fmt.Print(explanation)
+		return nil
+	},
+}
+
+// explain 以罗盘的具体数字说明解法满足的模 6 同余方程
+func explain(input compass.Compass, solution compass.Steps) (string, error) {
+	if _, err := compass.CheckSolution(input, solution); err != nil {
+		return "", err
+	}
+	std := input.Standardize()
+	effects := std.GroupEffects()
+	// 复合圈分组转动一次的位移是其中各圈分组位移之和
+	effectOf := func(step compass.Step) [3]int {
+		if !step.IsComposite() {
+			return effects[step.RingGroup]
+		}
+		var ret [3]int
+		for _, rg := range step.Composite {
+			for i, d := range effects[rg] {
+				ret[i] += d
+			}
+		}
+		return ret
+	}
+	solution = solution.Standardize()
+
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Compass:  %s\n", std.String())
+	fmt.Fprintf(w, "Solution: %s\n", solution.String())
+
+	// 各圈分组转动一次的位移
+	fmt.Fprintf(w, "\nEach click moves the rings clockwise by (in notches of 60 degrees):\n")
+	for _, rg := range std.RingGroups {
+		effect := effects[rg]
+		fmt.Fprintf(w, "  %s\touter %+d\tmiddle %+d\tinner %+d\n", rg.ShortName(), effect[0], effect[1], effect[2])
+	}
+	for _, composite := range std.CompositeGroups {
+		step := compass.Step{Composite: composite, Count: 1}
+		effect := effectOf(step)
+		fmt.Fprintf(w, "  %s\touter %+d\tmiddle %+d\tinner %+d\n", stepName(step), effect[0], effect[1], effect[2])
+	}
+
+	// 各圈需要转动的距离
+	locations := [3]int{std.OuterRing.Location, std.MiddleRing.Location, std.InnerRing.Location}
+	var needs [3]int
+	fmt.Fprintf(w, "\nTo reach the target location %d, each ring must move in total (mod 6):\n", compass.TargetLocation)
+	for i, loc := range locations {
+		needs[i] = mod6(compass.TargetLocation - loc)
+		fmt.Fprintf(w, "  %s\tat %d, needs %d\n", ringNames[i], loc, needs[i])
+	}
+
+	// 解法中各圈分组的转动次数满足的同余方程
+	counts := make([]string, len(solution))
+	for j, step := range solution {
+		counts[j] = fmt.Sprintf("%s %d times", stepName(step), step.Count)
+		if step.Count == 1 {
+			counts[j] = fmt.Sprintf("%s once", stepName(step))
+		}
+	}
+	if len(solution) == 0 {
+		fmt.Fprintf(w, "\nThe solution clicks nothing:\n")
+	} else {
+		fmt.Fprintf(w, "\nThe solution clicks %s:\n", strings.Join(counts, ", "))
+	}
+	solved := true
+	for i := range ringNames {
+		terms := make([]string, len(solution))
+		sum := 0
+		for j, step := range solution {
+			d := effectOf(step)[i]
+			terms[j] = fmt.Sprintf("%d×(%+d)", step.Count, d)
+			sum += step.Count * d
+		}
+		if len(terms) == 0 {
+			terms = []string{"0"}
+		}
+		mark := "✓"
+		if mod6(sum) != needs[i] {
+			mark = "✗"
+			solved = false
+		}
+		fmt.Fprintf(w, "  %s\t%s = %d ≡ %d (mod 6)\t%s\n", ringNames[i], strings.Join(terms, " + "), sum, mod6(sum), mark)
+	}
+	if solved {
+		fmt.Fprintf(w, "\nEvery ring moves exactly as far as it needs, so the compass is solved.\n")
+	} else {
+		fmt.Fprintf(w, "\nSome rings do not move as far as they need, so the compass is not solved.\n")
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// stepName 返回步骤转动的圈分组或复合圈分组的名称，即不含转动次数的字符串表示，比如 "om" 或 "(o+mi)"
+func stepName(step compass.Step) string {
+	step.Count = 1
+	return strings.TrimSuffix(step.String(), "1")
+}
+
+// mod6 返回 x 模 6 的非负余数
+func mod6(x int) int {
+	return (x%6 + 6) % 6
+}
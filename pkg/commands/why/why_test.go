@@ -0,0 +1,50 @@
+package why
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestExplain 测试 explain
+func TestExplain(t *testing.T) {
+	input, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	solution, err := compass.ParseSteps("om2,mi2,oi4")
+	if err != nil {
+		t.Fatalf("parse solution error: %s", err)
+	}
+	ret, err := explain(input, solution)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, expected := range []string{
+		"Solution: mi2,oi4,om2\n",
+		"  om  outer +1  middle -4  inner +0\n",
+		"  middle  at 4, needs 2\n",
+		"The solution clicks mi 2 times, oi 4 times, om 2 times:\n",
+		"  middle  2×(-4) + 4×(+0) + 2×(-4) = -16 ≡ 2 (mod 6)  ✓\n",
+		"so the compass is solved.\n",
+	} {
+		if !strings.Contains(ret, expected) {
+			t.Errorf("unexpected result: %s (expected to contain: %#v)", ret, expected)
+		}
+	}
+
+	// 解不开
+	ret, err = explain(input, compass.Steps{{RingGroup: compass.OuterInnerRingGroup, Count: 1}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(ret, "clicks oi once:") || !strings.Contains(ret, "✗") || !strings.Contains(ret, "not solved") {
+		t.Errorf("unexpected result: %s (expected to be not solved)", ret)
+	}
+
+	// 不支持的圈分组
+	if _, err := explain(input, compass.Steps{{RingGroup: compass.OuterRingGroup, Count: 1}}); err == nil {
+		t.Errorf("expected error with unsupported ring group")
+	}
+}
@@ -0,0 +1,164 @@
+package compass
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// IsCommutative 判断罗盘是否满足基本的可交换模型，即没有复合圈分组，也没有通过 GroupEffect 指定圈分组的位移
+// 满足时解法只取决于各圈分组的转动次数，可以使用 SolveAlgebraic 求解，默认求解器也会据此直接解方程组。
+// 需要避开部分状态时（参见 SolverOptions.Avoid ）转动顺序同样会影响结果，但这是求解器的设置，不在此判断
+func (compass *Compass) IsCommutative() bool {
+	return compass != nil && len(compass.CompositeGroups) == 0 && len(compass.GroupEffect) == 0
+}
+
+// SolveAlgebraic 以解模 6 线性方程组的方式求解罗盘，返回总转动次数最少时每个支持的圈分组的转动次数（ 0-5 ）
+// 各圈分组的转动可以交换顺序，因此解法只取决于各圈分组的转动次数 x ，满足 A·x ≡ TargetLocation - location (mod 6) ，
+// 其中 A 的每一列是一个圈分组转动一次时各圈的位移。由于 Z/6 ≅ Z/2 × Z/3 ，分别求出模 2 和模 3 下的所有解，
+// 再按中国剩余定理组合并取总转动次数最少的，不需要搜索状态空间。
+// 罗盘无解时返回包装了 ErrUnsolvable 的错误；罗盘包含复合圈分组时返回错误，此时应使用 Solver 求解
+func (compass *Compass) SolveAlgebraic() (map[RingGroup]int, error) {
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if len(compass.CompositeGroups) > 0 {
+		return nil, fmt.Errorf("algebraic solving does not support composite groups")
+	}
+	std := compass.standardized()
+
+	// 方程组 A·x ≡ b (mod 6)
+	n := len(std.RingGroups)
+	a := make([][3]int, n)
+	for j, rg := range std.RingGroups {
+		effect := std.groupEffect(rg)
+		for r := range effect {
+			a[j][r] = (effect[r]%6 + 6) % 6
+		}
+	}
+	b := [3]int{
+		((TargetLocation-std.OuterRing.Location)%6 + 6) % 6,
+		((TargetLocation-std.MiddleRing.Location)%6 + 6) % 6,
+		((TargetLocation-std.InnerRing.Location)%6 + 6) % 6,
+	}
+
+	mod2 := solveModPrime(a, b, 2)
+	mod3 := solveModPrime(a, b, 3)
+	if len(mod2) == 0 || len(mod3) == 0 {
+		return nil, fmt.Errorf("%w: the linear system of ring group effects has no solution modulo 6", ErrUnsolvable)
+	}
+
+	// 按中国剩余定理组合，取总转动次数最少的解
+	// 总转动次数相同时，与默认求解器一致，取把转动次数看作六进制数（最后一个圈分组为最高位）最小的解
+	var best []int
+	bestTotal, bestCode := -1, 0
+	x := make([]int, n)
+	for _, x2 := range mod2 {
+		for _, x3 := range mod3 {
+			total, code := 0, 0
+			for j := n - 1; j >= 0; j-- {
+				// 0-5 中模 2 余 x2[j] 且模 3 余 x3[j] 的数
+				x[j] = (3*x2[j] + 4*x3[j]) % 6
+				total += x[j]
+				code = code*6 + x[j]
+			}
+			if bestTotal < 0 || total < bestTotal || (total == bestTotal && code < bestCode) {
+				best = append(best[:0], x...)
+				bestTotal, bestCode = total, code
+			}
+		}
+	}
+
+	ret := make(map[RingGroup]int, n)
+	for j, rg := range std.RingGroups {
+		ret[rg] = best[j]
+	}
+	return ret, nil
+}
+
+// GeneratingGroups 返回当前罗盘支持的圈分组（按标准化顺序）中，转动一次时各圈位移张成的子群与全部圈分组相同的最小子集
+// 即把各圈分组的位移看作 (Z/6)³ 中的向量，去掉可以由其余圈分组组合出的多余圈分组，余下的个数就是罗盘真正的自由度。
+// 这只关心各圈分组能组合出的所有位移，与罗盘的初始位置及能否解开无关。
+// 复合圈分组只是其成员的组合，不会扩大张成的子群，因此不在考虑范围内。
+// 同样大小的子集有多个时，返回按标准化顺序最靠前的
+func (compass *Compass) GeneratingGroups() []RingGroup {
+	if compass == nil {
+		return nil
+	}
+	std := compass.standardized()
+	n := len(std.RingGroups)
+	full := spanSize(std, std.RingGroups)
+
+	// 圈分组至多 6 个，按子集大小从小到大枚举
+	for size := 0; size <= n; size++ {
+		for mask := 0; mask < 1<<n; mask++ {
+			if bits.OnesCount(uint(mask)) != size {
+				continue
+			}
+			var rgs []RingGroup
+			for j, rg := range std.RingGroups {
+				if mask&(1<<j) > 0 {
+					rgs = append(rgs, rg)
+				}
+			}
+			if spanSize(std, rgs) == full {
+				return rgs
+			}
+		}
+	}
+	return nil
+}
+
+// spanSize 返回 rgs 中各圈分组转动一次时各圈位移在 (Z/6)³ 中张成的子群的大小
+// 有限群中的正整数倍组合即可得到全部元素，因此从 0 出发按各圈分组转动即可遍历整个子群
+func spanSize(compass *Compass, rgs []RingGroup) int {
+	var visited [216]bool
+	queue := []int{0}
+	visited[0] = true
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, rg := range rgs {
+			next := rotateHash(compass, cur, rg)
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	count := 0
+	for _, v := range visited {
+		if v {
+			count++
+		}
+	}
+	return count
+}
+
+// solveModPrime 返回方程组 A·x ≡ b (mod p) 的所有解，参数 a 的每个元素是 A 的一列
+// 圈分组至多 6 个，解空间至多 3^6 个元素，因此直接枚举
+func solveModPrime(a [][3]int, b [3]int, p int) [][]int {
+	n := len(a)
+	size := 1
+	for i := 0; i < n; i++ {
+		size *= p
+	}
+	var ret [][]int
+	for code := 0; code < size; code++ {
+		x := make([]int, n)
+		for j, c := 0, code; j < n; j, c = j+1, c/p {
+			x[j] = c % p
+		}
+		ok := true
+		for r := 0; r < 3 && ok; r++ {
+			sum := 0
+			for j := range x {
+				sum += a[j][r] * x[j]
+			}
+			ok = (sum-b[r])%p == 0
+		}
+		if ok {
+			ret = append(ret, x)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,189 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestCompassSolveAlgebraic 测试 Compass.SolveAlgebraic 方法，与广度优先搜索的结果比较
+func TestCompassSolveAlgebraic(t *testing.T) {
+	configs := []struct {
+		rgs    []RingGroup
+		speeds [3]int
+	}{
+		{rgs: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup}, speeds: [3]int{1, -4, 2}},
+		{rgs: []RingGroup{OuterRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup}, speeds: [3]int{1, 2, 1}},
+		{rgs: []RingGroup{OuterRingGroup, MiddleRingGroup}, speeds: [3]int{3, -2, 1}},
+		{rgs: []RingGroup{OuterRingGroup, MiddleRingGroup, InnerRingGroup, OuterMiddleRingGroup}, speeds: [3]int{2, 3, -1}},
+	}
+	solved := &Compass{}
+	for _, config := range configs {
+		for hash := 0; hash < 216; hash++ {
+			c := Compass{
+				OuterRing:  Ring{Location: hash / 36, Speed: config.speeds[0]},
+				MiddleRing: Ring{Location: hash / 6 % 6, Speed: config.speeds[1]},
+				InnerRing:  Ring{Location: hash % 6, Speed: config.speeds[2]},
+				RingGroups: config.rgs,
+			}
+			counts, err := c.SolveAlgebraic()
+			distance := c.DistanceTo(solved)
+			if distance < 0 {
+				if !errors.Is(err, ErrUnsolvable) {
+					t.Errorf("unexpected error of %s: %v (expected: %s)", c.String(), err, ErrUnsolvable)
+				}
+				continue
+			}
+			if err != nil {
+				t.Errorf("unexpected error of %s: %s", c.String(), err)
+				continue
+			}
+			var steps Steps
+			for rg, count := range counts {
+				steps = append(steps, Step{RingGroup: rg, Count: count})
+			}
+			if ok, _ := CheckSolution(c, steps); !ok {
+				t.Errorf("unexpected result of %s: %s (not a solution)", c.String(), steps)
+			}
+			if steps.TotalCount() != distance {
+				t.Errorf("unexpected moves of %s: %d (expected: %d)", c.String(), steps.TotalCount(), distance)
+			}
+		}
+	}
+
+	// 不支持复合圈分组
+	c := Compass{
+		RingGroups:      []RingGroup{OuterRingGroup, MiddleRingGroup},
+		CompositeGroups: [][]RingGroup{{OuterRingGroup, MiddleRingGroup}},
+	}
+	if _, err := c.SolveAlgebraic(); err == nil {
+		t.Errorf("expected error with composite groups")
+	}
+}
+
+// TestCompassIsCommutative 测试 Compass.IsCommutative 方法
+func TestCompassIsCommutative(t *testing.T) {
+	rgs := []RingGroup{OuterRingGroup, MiddleRingGroup}
+	cases := []struct {
+		compass     *Compass
+		expectedRet bool
+	}{
+		{compass: nil, expectedRet: false},
+		{compass: &Compass{RingGroups: rgs}, expectedRet: true},
+		{compass: &Compass{RingGroups: rgs, CompositeGroups: [][]RingGroup{rgs}}, expectedRet: false},
+		{compass: &Compass{RingGroups: rgs, GroupEffect: map[RingGroup][3]int{OuterRingGroup: {2, 0, 0}}}, expectedRet: false},
+	}
+	for i, c := range cases {
+		if ret := c.compass.IsCommutative(); ret != c.expectedRet {
+			t.Errorf("unexpected result of case %d: %t (expected: %t)", i, ret, c.expectedRet)
+		}
+	}
+}
+
+// TestCompassGeneratingGroups 测试 Compass.GeneratingGroups 方法
+func TestCompassGeneratingGroups(t *testing.T) {
+	r := Ring{Speed: 1}
+	cases := []struct {
+		compass     *Compass
+		expectedRet []RingGroup
+	}{
+		{compass: nil, expectedRet: nil},
+		// om 可以由 o 和 m 组合出
+		{
+			compass:     &Compass{OuterRing: r, MiddleRing: r, InnerRing: r, RingGroups: []RingGroup{OuterRingGroup, OuterMiddleRingGroup, MiddleRingGroup}},
+			expectedRet: []RingGroup{MiddleRingGroup, OuterRingGroup},
+		},
+		// 三个单圈分组缺一不可
+		{
+			compass:     &Compass{OuterRing: r, MiddleRing: r, InnerRing: r, RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, InnerRingGroup}},
+			expectedRet: []RingGroup{InnerRingGroup, MiddleRingGroup, OuterRingGroup},
+		},
+		// om 转动一次的位移是 o 的 2 倍
+		{
+			compass: &Compass{
+				OuterRing:   r,
+				MiddleRing:  r,
+				InnerRing:   r,
+				RingGroups:  []RingGroup{OuterMiddleRingGroup, OuterRingGroup},
+				GroupEffect: map[RingGroup][3]int{OuterRingGroup: {1, 0, 0}, OuterMiddleRingGroup: {2, 0, 0}},
+			},
+			expectedRet: []RingGroup{OuterRingGroup},
+		},
+	}
+	for i, c := range cases {
+		ret := c.compass.GeneratingGroups()
+		if !reflect.DeepEqual(ret, c.expectedRet) {
+			t.Errorf("unexpected result of case %d: %v (expected: %v)", i, ret, c.expectedRet)
+		}
+	}
+}
+
+// TestDefaultSolverAlgebraic 测试默认求解器直接解方程组与搜索的结果完全一致
+// 指定 Trace 时默认求解器总是搜索
+func TestDefaultSolverAlgebraic(t *testing.T) {
+	algebraic, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	search, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard(), Trace: io.Discard})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	for _, rgs := range [][]RingGroup{
+		{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+		{OuterRingGroup, MiddleRingGroup, InnerRingGroup, OuterMiddleRingGroup},
+	} {
+		for hash := 0; hash < 216; hash++ {
+			c := Compass{
+				OuterRing:  Ring{Location: hash / 36, Speed: 1},
+				MiddleRing: Ring{Location: hash / 6 % 6, Speed: -4},
+				InnerRing:  Ring{Location: hash % 6, Speed: 2},
+				RingGroups: rgs,
+			}
+			ret, err := algebraic.Solve(context.Background(), c)
+			expectedRet, expectedErr := search.Solve(context.Background(), c)
+			if (err == nil) != (expectedErr == nil) || (err != nil && err.Error() != expectedErr.Error()) {
+				t.Errorf("unexpected error of %s: %v (expected: %v)", c.String(), err, expectedErr)
+				continue
+			}
+			if ret.String() != expectedRet.String() {
+				t.Errorf("unexpected result of %s: %s (expected: %s)", c.String(), ret.String(), expectedRet.String())
+			}
+		}
+	}
+}
+
+// benchmarkCompass 基准测试使用的罗盘
+var benchmarkCompass = Compass{
+	OuterRing:  Ring{Location: 0, Speed: 1},
+	MiddleRing: Ring{Location: 4, Speed: -4},
+	InnerRing:  Ring{Location: 0, Speed: 2},
+	RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+}
+
+// BenchmarkSolveAlgebraic 基准测试 Compass.SolveAlgebraic
+func BenchmarkSolveAlgebraic(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := benchmarkCompass.SolveAlgebraic(); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+// BenchmarkDefaultSolverSearch 基准测试默认求解器搜索求解，与 BenchmarkSolveAlgebraic 比较
+// 设置不会起作用的最大转动次数，使默认求解器搜索而不是直接解方程组
+func BenchmarkDefaultSolverSearch(b *testing.B) {
+	solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard(), GroupLimits: map[RingGroup]int{OuterInnerRingGroup: 6}})
+	if err != nil {
+		b.Fatalf("new default solver error: %s", err)
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := solver.Solve(context.Background(), benchmarkCompass); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewBalancedSolver 创建一个均衡引航罗盘求解器
+// 求解器在总转动次数最少的所有解法中，返回单个圈分组最大转动次数最小的解法
+func NewBalancedSolver(opts SolverOptions) (Solver, error) {
+	if err := validateGroupLimits(opts.GroupLimits); err != nil {
+		return nil, err
+	}
+	return &balancedSolver{
+		defaultSolver: defaultSolver{
+			logger:      opts.Logger,
+			trace:       opts.Trace,
+			groupLimits: opts.GroupLimits,
+		},
+	}, nil
+}
+
+// balancedSolver 均衡引航罗盘求解器
+type balancedSolver struct {
+	defaultSolver
+}
+
+var _ Solver = &balancedSolver{}
+
+// Solve 求解引航罗盘
+func (s *balancedSolver) Solve(_ context.Context, compass Compass) (Steps, error) {
+	// 校验入参
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if err := compass.PreSolveCheck(); err != nil {
+		return nil, err
+	}
+
+	// 可能的解法已按总转动次数排序，在总转动次数最少的有效解法中选择
+	var (
+		best  Steps
+		found bool
+	)
+	for _, solution := range s.getPossibleSolutions(compass) {
+		if found && solution.TotalCount() > best.TotalCount() {
+			break
+		}
+		if ok, _ := CheckSolution(compass, solution); !ok {
+			continue
+		}
+		if !found || solution.MaxCount() < best.MaxCount() {
+			best, found = solution, true
+		}
+	}
+	if !found {
+		return nil, s.unsolvableError(compass)
+	}
+	return best.Standardize(), nil
+}
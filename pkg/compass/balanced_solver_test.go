@@ -0,0 +1,49 @@
+package compass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestBalancedSolver 测试均衡求解器
+func TestBalancedSolver(t *testing.T) {
+	c := Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: 2},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+	}
+
+	// 默认求解器给出的解法只转动一个圈分组
+	defaultSolver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+	defaultRet, err := defaultSolver.Solve(context.Background(), c)
+	if err != nil {
+		t.Errorf("compass solve error: %s", err)
+		return
+	}
+
+	// 均衡求解器给出的解法总转动次数相同，但单个圈分组最大转动次数更小
+	solver, err := NewBalancedSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new balanced solver error: %s", err)
+		return
+	}
+	ret, err := solver.Solve(context.Background(), c)
+	if err != nil {
+		t.Errorf("compass solve error: %s", err)
+		return
+	}
+	expectedRet := "o2,om3"
+	if ret.String() != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret.String(), expectedRet)
+	}
+	if ret.TotalCount() != defaultRet.TotalCount() || ret.MaxCount() >= defaultRet.MaxCount() {
+		t.Errorf("unexpected result: %#v (expected to be more balanced than %#v)", ret.String(), defaultRet.String())
+	}
+}
@@ -0,0 +1,55 @@
+package compass
+
+import (
+	"fmt"
+)
+
+// Builder 以链式调用的方式构造罗盘
+//
+//	c, err := NewBuilder().Outer(3, 1).Middle(0, -2).Inner(5, 0).
+//		Group(OuterRingGroup).Group(MiddleInnerRingGroup).
+//		Build()
+type Builder struct {
+	compass Compass
+}
+
+// NewBuilder 创建一个罗盘构造器
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Outer 设置外圈的位置和旋转速度
+func (b *Builder) Outer(location, speed int) *Builder {
+	b.compass.OuterRing = Ring{Location: location, Speed: speed}
+	return b
+}
+
+// Middle 设置中圈的位置和旋转速度
+func (b *Builder) Middle(location, speed int) *Builder {
+	b.compass.MiddleRing = Ring{Location: location, Speed: speed}
+	return b
+}
+
+// Inner 设置内圈的位置和旋转速度
+func (b *Builder) Inner(location, speed int) *Builder {
+	b.compass.InnerRing = Ring{Location: location, Speed: speed}
+	return b
+}
+
+// Group 添加一个或多个圈分组
+func (b *Builder) Group(rgs ...RingGroup) *Builder {
+	b.compass.RingGroups = append(b.compass.RingGroups, rgs...)
+	return b
+}
+
+// Build 校验并返回构造的罗盘
+// 返回的罗盘与构造器互不影响，构造器可以继续使用
+func (b *Builder) Build() (*Compass, error) {
+	c := b.compass
+	c.RingGroups = make([]RingGroup, len(b.compass.RingGroups))
+	copy(c.RingGroups, b.compass.RingGroups)
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	return &c, nil
+}
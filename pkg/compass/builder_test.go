@@ -0,0 +1,28 @@
+package compass
+
+import (
+	"testing"
+)
+
+// TestBuilder 测试 Builder
+func TestBuilder(t *testing.T) {
+	c, err := NewBuilder().Outer(3, 1).Middle(0, -2).Inner(5, 0).
+		Group(OuterRingGroup).Group(MiddleInnerRingGroup).
+		Build()
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	expectedRet := "3+1,0-2,5+0/mi,o"
+	if c.String() != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", c.String(), expectedRet)
+	}
+
+	// 非法的罗盘
+	if _, err := NewBuilder().Outer(6, 1).Group(OuterRingGroup).Build(); err == nil {
+		t.Errorf("expected error building compass with invalid location")
+	}
+	if _, err := NewBuilder().Group(OuterRingGroup | MiddleInnerRingGroup).Build(); err == nil {
+		t.Errorf("expected error building compass with invalid ring group")
+	}
+}
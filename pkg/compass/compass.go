@@ -3,14 +3,23 @@ package compass
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 )
 
+// TargetLocation 目标位置，即罗盘正左方向
+// 各圈的位置以指针从目标位置沿顺时针方向旋转到当前位置所需旋转的角度除以 60 度表示，因此目标位置总是 0 ，
+// 所有圈都位于目标位置时罗盘即已解决
+const TargetLocation = 0
+
+// targetHash 所有圈都位于目标位置时的状态，参见 Compass.Hash
+const targetHash = TargetLocation*36 + TargetLocation*6 + TargetLocation
+
 // Ring 引航罗盘中的一圈
 type Ring struct {
 	// 位置
 	// 指针从目标位置（即罗盘正左方向）沿顺时针方向旋转到当前位置所需旋转的角度处以 60 度，
-	// 比如 0 表示目标位置， 3 表示指针指向正右方向
+	// 比如 0 表示目标位置（参见 TargetLocation ）， 3 表示指针指向正右方向
 	// 因为一周是 360 度，因此该字段有效范围是： 0-5
 	Location int
 	// 旋转速度
@@ -19,6 +28,29 @@ type Ring struct {
 	Speed int
 }
 
+// SpeedFromNotches 将游戏中的旋转刻度数及方向转为 Ring.Speed
+// 游戏中每次旋转经过的刻度数 notches 即旋转速度的大小，单位为 60 度；
+// 顺时针（ clockwise 为 true ）旋转时速度为正，逆时针旋转时速度为负。
+// 比如每次逆时针旋转 2 个刻度对应的速度为 -2
+func SpeedFromNotches(notches int, clockwise bool) int {
+	if notches < 0 {
+		notches = -notches
+	}
+	if clockwise {
+		return notches
+	}
+	return -notches
+}
+
+// NotchesFromSpeed 将 Ring.Speed 转为游戏中的旋转刻度数及方向，是 SpeedFromNotches 的逆运算
+// 速度为 0 时视为顺时针
+func NotchesFromSpeed(speed int) (notches int, clockwise bool) {
+	if speed < 0 {
+		return -speed, false
+	}
+	return speed, true
+}
+
 // RingGroup 引航罗盘圈分组
 type RingGroup uint8
 
@@ -76,6 +108,7 @@ func (rg RingGroup) String() string {
 }
 
 // Compass 引航罗盘
+// Compass 的方法不是并发安全的，需要在多个 goroutine 间共享并修改同一个罗盘时请使用 SafeCompass
 type Compass struct {
 	// 内圈
 	InnerRing Ring
@@ -86,13 +119,162 @@ type Compass struct {
 	// 圈分组
 	// 可以同时旋转的一个或多个圈组成一个分组
 	RingGroups []RingGroup
+	// 复合圈分组
+	// 部分罗盘可以同时转动多个圈分组，作为一次转动，效果为其中各圈分组分别转动一次的叠加。
+	// 每个元素是一组可以同时转动的圈分组，其中的圈分组必须都在 RingGroups 中
+	CompositeGroups [][]RingGroup
+	// 圈分组位移
+	// 部分罗盘中同一个圈在不同圈分组中的旋转速度不同，此时可以为圈分组指定转动一次时外圈、中圈、内圈的位移，
+	// 求解及转动时优先于各圈的旋转速度。其中的圈分组必须都在 RingGroups 中，不在其中的圈分组仍按各圈的旋转速度转动。
+	// 字符串表示中不包含该字段
+	GroupEffect map[RingGroup][3]int
 }
 
-// Validate TODO 合法化
+// Validate 合法化
+// 校验各圈位置在有效范围内，且各圈分组都是合法值，不合法时返回包装了 ErrInvalidCompass 的错误
 func (compass *Compass) Validate() error {
+	if compass == nil {
+		return fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	for _, r := range []struct {
+		name string
+		ring Ring
+	}{
+		{name: "outer", ring: compass.OuterRing},
+		{name: "middle", ring: compass.MiddleRing},
+		{name: "inner", ring: compass.InnerRing},
+	} {
+		if r.ring.Location < 0 || r.ring.Location > 5 {
+			return fmt.Errorf("%w: location of %s ring out of range: %d (must be in range 0-5)", ErrInvalidCompass, r.name, r.ring.Location)
+		}
+	}
+	for i, rg := range compass.RingGroups {
+		if rg.Name() == "" {
+			return fmt.Errorf("%w: unknown ring group at index %d: %#b", ErrInvalidCompass, i, uint8(rg))
+		}
+	}
+	if err := compass.validateCompositeGroups(); err != nil {
+		return err
+	}
+	return compass.validateGroupEffect()
+}
+
+// Clone 返回罗盘的深拷贝
+func (compass *Compass) Clone() *Compass {
+	if compass == nil {
+		return nil
+	}
+	ret := *compass
+	if compass.RingGroups != nil {
+		ret.RingGroups = make([]RingGroup, len(compass.RingGroups))
+		copy(ret.RingGroups, compass.RingGroups)
+	}
+	if compass.CompositeGroups != nil {
+		ret.CompositeGroups = make([][]RingGroup, len(compass.CompositeGroups))
+		for i, composite := range compass.CompositeGroups {
+			ret.CompositeGroups[i] = make([]RingGroup, len(composite))
+			copy(ret.CompositeGroups[i], composite)
+		}
+	}
+	if compass.GroupEffect != nil {
+		ret.GroupEffect = make(map[RingGroup][3]int, len(compass.GroupEffect))
+		for rg, effect := range compass.GroupEffect {
+			ret.GroupEffect[rg] = effect
+		}
+	}
+	return &ret
+}
+
+// Rotate 按圈分组转动一次罗盘
+// 圈分组包含的各圈按各自的旋转速度（或 GroupEffect 中指定的位移）转动，转动后的位置在有效范围 0-5 内
+func (compass *Compass) Rotate(rg RingGroup) error {
+	if compass == nil {
+		return fmt.Errorf("compass is nil")
+	}
+	if !compass.IsRingGroupSupported(rg) {
+		return fmt.Errorf("ring group not supported by compass: %s (must be one of %v)", rg.Name(), compass.RingGroups)
+	}
+	effect := compass.groupEffect(rg)
+	compass.OuterRing.Location = ((compass.OuterRing.Location+effect[0])%6 + 6) % 6
+	compass.MiddleRing.Location = ((compass.MiddleRing.Location+effect[1])%6 + 6) % 6
+	compass.InnerRing.Location = ((compass.InnerRing.Location+effect[2])%6 + 6) % 6
 	return nil
 }
 
+// ApplySteps 按步骤依次转动罗盘，转动复合圈分组时依次转动其中各圈分组
+// 步骤中有不支持的圈分组或复合圈分组时返回错误，此时罗盘保持原样
+func (compass *Compass) ApplySteps(steps Steps) error {
+	if compass == nil {
+		return fmt.Errorf("compass is nil")
+	}
+	result := *compass
+	for _, step := range steps {
+		rgs := []RingGroup{step.RingGroup}
+		if step.IsComposite() {
+			if !compass.IsCompositeGroupSupported(step.Composite) {
+				return fmt.Errorf("composite group not supported by compass: %s", "("+compositeKey(step.Composite)+")")
+			}
+			rgs = step.Composite
+		}
+		for i := 0; i < step.Count; i++ {
+			for _, rg := range rgs {
+				if err := result.Rotate(rg); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	compass.OuterRing = result.OuterRing
+	compass.MiddleRing = result.MiddleRing
+	compass.InnerRing = result.InnerRing
+	return nil
+}
+
+// IsSolved 判断罗盘是否已解决，即各圈都位于目标位置
+func (compass *Compass) IsSolved() bool {
+	if compass == nil {
+		return false
+	}
+	return compass.Hash() == targetHash
+}
+
+// RingOffset 一个不在目标位置的圈及其离目标位置的距离
+type RingOffset struct {
+	// 圈，即 OuterRingGroup 、 MiddleRingGroup 或 InnerRingGroup
+	Ring RingGroup
+	// 位置
+	Location int
+	// 离目标位置的距离，即沿任一方向转到目标位置所需的位置数（ 1-3 ）
+	Distance int
+}
+
+// OffTargetRings 依次返回外圈、中圈、内圈中不在目标位置的圈及其离目标位置的距离，罗盘已解决时返回 nil
+func (compass *Compass) OffTargetRings() []RingOffset {
+	if compass == nil {
+		return nil
+	}
+	var ret []RingOffset
+	for _, r := range []struct {
+		rg   RingGroup
+		ring Ring
+	}{
+		{rg: OuterRingGroup, ring: compass.OuterRing},
+		{rg: MiddleRingGroup, ring: compass.MiddleRing},
+		{rg: InnerRingGroup, ring: compass.InnerRing},
+	} {
+		loc := (r.ring.Location%6 + 6) % 6
+		d := (loc - TargetLocation + 6) % 6
+		if d == 0 {
+			continue
+		}
+		if d > 3 {
+			d = 6 - d
+		}
+		ret = append(ret, RingOffset{Ring: r.rg, Location: loc, Distance: d})
+	}
+	return ret
+}
+
 // IsRingGroupSupported 判断指定圈分组是否是当前罗盘支持的
 func (compass *Compass) IsRingGroupSupported(rg RingGroup) bool {
 	if compass == nil {
@@ -106,8 +288,116 @@ func (compass *Compass) IsRingGroupSupported(rg RingGroup) bool {
 	return false
 }
 
+// GroupEffects 返回当前罗盘支持的各圈分组转动一次时各圈的位移
+// 位移依次为外圈、中圈、内圈，即圈分组包含的圈的旋转速度，不包含的圈为 0 ；
+// GroupEffect 中指定了位移的圈分组以其为准
+func (compass *Compass) GroupEffects() map[RingGroup][3]int {
+	if compass == nil {
+		return nil
+	}
+	std := compass.standardized()
+	ret := make(map[RingGroup][3]int, len(std.RingGroups))
+	for _, rg := range std.RingGroups {
+		ret[rg] = std.groupEffect(rg)
+	}
+	return ret
+}
+
+// Hash 返回罗盘各圈位置的哈希值
+// 只与标准化后的各圈位置有关，与旋转速度和圈分组无关，取值范围是： 0-215
+func (compass *Compass) Hash() int {
+	if compass == nil {
+		return 0
+	}
+	outer := (compass.OuterRing.Location%6 + 6) % 6
+	middle := (compass.MiddleRing.Location%6 + 6) % 6
+	inner := (compass.InnerRing.Location%6 + 6) % 6
+	return outer*36 + middle*6 + inner
+}
+
+// Equal 判断两个罗盘是否完全相同
+// 标准化后比较各圈位置、旋转速度、圈分组及各圈分组的位移，用于判断两个罗盘是否是同一个谜题，比如校验解析结果
+func (compass *Compass) Equal(other *Compass) bool {
+	if compass == nil || other == nil {
+		return compass == other
+	}
+	a, b := compass.standardized(), other.standardized()
+	if a.OuterRing != b.OuterRing || a.MiddleRing != b.MiddleRing || a.InnerRing != b.InnerRing {
+		return false
+	}
+	if len(a.RingGroups) != len(b.RingGroups) {
+		return false
+	}
+	for i := range a.RingGroups {
+		if a.RingGroups[i] != b.RingGroups[i] {
+			return false
+		}
+	}
+	if len(a.CompositeGroups) != len(b.CompositeGroups) {
+		return false
+	}
+	for i := range a.CompositeGroups {
+		if compositeKey(a.CompositeGroups[i]) != compositeKey(b.CompositeGroups[i]) {
+			return false
+		}
+	}
+	return groupEffectEqual(a, b)
+}
+
+// SamePositions 判断两个罗盘各圈位置是否相同
+// 只比较标准化后的各圈位置，忽略旋转速度和圈分组。
+// 求解过程中旋转速度和圈分组不会变化，因此判断搜索状态是否相同时应使用该方法（或 Hash ）
+func (compass *Compass) SamePositions(other *Compass) bool {
+	if compass == nil || other == nil {
+		return compass == other
+	}
+	return compass.Hash() == other.Hash()
+}
+
+// IsStandardized 判断罗盘是否已经是标准化的形式
+// 即各圈位置在 0-5 内，旋转速度在 -5 到 5 之间，且圈分组及复合圈分组已排序并去重
+func (compass *Compass) IsStandardized() bool {
+	if compass == nil {
+		return true
+	}
+	for _, r := range []Ring{compass.OuterRing, compass.MiddleRing, compass.InnerRing} {
+		if r.Location < 0 || r.Location > 5 || r.Speed%6 != r.Speed {
+			return false
+		}
+	}
+	for i := 1; i < len(compass.RingGroups); i++ {
+		if compass.RingGroups[i-1] >= compass.RingGroups[i] {
+			return false
+		}
+	}
+	return isCompositeGroupsStandardized(compass.CompositeGroups) && isGroupEffectStandardized(compass.GroupEffect)
+}
+
+// standardized 返回标准化的罗盘，已经是标准化形式时直接返回自身以避免拷贝
+// 返回值只能用于读取
+func (compass *Compass) standardized() *Compass {
+	if compass.IsStandardized() {
+		return compass
+	}
+	return compass.Standardize()
+}
+
 // Standardize 标准化
+// 各圈位置及旋转速度对 6 取模，圈分组及复合圈分组排序、去重，参见 IsStandardized
 func (compass *Compass) Standardize() *Compass {
+	ret := compass.StandardizeLocations()
+	if ret == nil {
+		return nil
+	}
+	ret.OuterRing.Speed %= 6
+	ret.MiddleRing.Speed %= 6
+	ret.InnerRing.Speed %= 6
+	return ret
+}
+
+// StandardizeLocations 部分标准化，只标准化各圈位置及圈分组，保留旋转速度的原始写法
+// 比如旋转速度 +7 不会变为 +1 ，求解结果与 Standardize 后的罗盘相同
+func (compass *Compass) StandardizeLocations() *Compass {
 	if compass == nil {
 		return nil
 	}
@@ -129,33 +419,52 @@ func (compass *Compass) Standardize() *Compass {
 	return &Compass{
 		InnerRing: Ring{
 			Location: (compass.InnerRing.Location%6 + 6) % 6,
-			Speed:    compass.InnerRing.Speed % 6,
+			Speed:    compass.InnerRing.Speed,
 		},
 		MiddleRing: Ring{
 			Location: (compass.MiddleRing.Location%6 + 6) % 6,
-			Speed:    compass.MiddleRing.Speed % 6,
+			Speed:    compass.MiddleRing.Speed,
 		},
 		OuterRing: Ring{
 			Location: (compass.OuterRing.Location%6 + 6) % 6,
-			Speed:    compass.OuterRing.Speed % 6,
+			Speed:    compass.OuterRing.Speed,
 		},
-		RingGroups: deduplicatedRGs,
+		RingGroups:      deduplicatedRGs,
+		CompositeGroups: standardizeCompositeGroups(compass.CompositeGroups),
+		GroupEffect:     standardizeGroupEffect(compass.GroupEffect),
 	}
 }
 
 // String 转为字符串表示
+// 先完整标准化，因此相同的罗盘总是得到相同的字符串，比如旋转速度 +7 表示为 +1
 func (compass *Compass) String() string {
 	if compass == nil {
 		return ""
 	}
+	return standardString(compass.standardized())
+}
 
-	// 标准化
-	std := compass.Standardize()
+// RawSpeedString 转为保留旋转速度原始写法的字符串表示，仅用于展示
+// 与 String 结构相同，但只经过 StandardizeLocations ，旋转速度与输入一致（比如 +7 ），
+// 便于核对罗盘是否与游戏中抄录的一致；判断罗盘是否相同时应使用 String
+func (compass *Compass) RawSpeedString() string {
+	if compass == nil {
+		return ""
+	}
+	return standardString(compass.StandardizeLocations())
+}
+
+// standardString 返回（至少部分）标准化的罗盘的字符串表示
+func standardString(std *Compass) string {
 	// 转换 RingGroups
-	rgStrs := make([]string, len(std.RingGroups))
+	rgStrs := make([]string, len(std.RingGroups), len(std.RingGroups)+len(std.CompositeGroups))
 	for i := range rgStrs {
 		rgStrs[i] = std.RingGroups[i].ShortName()
 	}
+	// 转换 CompositeGroups
+	for _, composite := range std.CompositeGroups {
+		rgStrs = append(rgStrs, "("+compositeKey(composite)+")")
+	}
 	rgsStr := strings.Join(rgStrs, ",")
 	// 组合
 	return fmt.Sprintf(
@@ -169,3 +478,44 @@ func (compass *Compass) String() string {
 		rgsStr,
 	)
 }
+
+// ArrowString 转为便于阅读的字符串表示，仅用于展示
+// 与 String 结构相同，但各圈的旋转速度以方向箭头（ ↻ 顺时针、 ↺ 逆时针）加大小表示，
+// 旋转速度为 0 时以 · 表示，位于目标位置的圈在位置后以 ◎ 标记。
+// 比如 "0+1,4-4,0+2/mi,oi,om" 表示为 "0◎↻1,4↺4,0◎↻2/mi,oi,om"
+func (compass *Compass) ArrowString() string {
+	if compass == nil {
+		return ""
+	}
+
+	// 标准化
+	std := compass.standardized()
+	// 转换各圈
+	ringStrs := make([]string, 3)
+	for i, r := range []Ring{std.OuterRing, std.MiddleRing, std.InnerRing} {
+		ringStr := strconv.Itoa(r.Location)
+		if r.Location == TargetLocation {
+			ringStr += "◎"
+		}
+		switch {
+		case r.Speed > 0:
+			ringStr += "↻" + strconv.Itoa(r.Speed)
+		case r.Speed < 0:
+			ringStr += "↺" + strconv.Itoa(-r.Speed)
+		default:
+			ringStr += "·"
+		}
+		ringStrs[i] = ringStr
+	}
+	// 转换 RingGroups
+	rgStrs := make([]string, len(std.RingGroups), len(std.RingGroups)+len(std.CompositeGroups))
+	for i := range rgStrs {
+		rgStrs[i] = std.RingGroups[i].ShortName()
+	}
+	// 转换 CompositeGroups
+	for _, composite := range std.CompositeGroups {
+		rgStrs = append(rgStrs, "("+compositeKey(composite)+")")
+	}
+	// 组合
+	return strings.Join(ringStrs, ",") + "/" + strings.Join(rgStrs, ",")
+}
@@ -1,7 +1,11 @@
 package compass
 
 import (
+	"context"
+	"reflect"
 	"testing"
+
+	"github.com/go-logr/logr"
 )
 
 // TestCompassString 测试 Compass.String 方法
@@ -22,3 +26,285 @@ func TestCompassString(t *testing.T) {
 		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
 	}
 }
+
+// TestCompassRawSpeedString 测试 Compass.StandardizeLocations 和 Compass.RawSpeedString 方法
+func TestCompassRawSpeedString(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 6, Speed: 7},
+		MiddleRing: Ring{Location: -2, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: -8},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, MiddleInnerRingGroup},
+	}
+	if ret, expectedRet := c.RawSpeedString(), "0+7,4-4,0-8/mi,om"; ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+	// String 完整标准化
+	if ret, expectedRet := c.String(), "0+1,4-4,0-2/mi,om"; ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+	// 旋转速度不变，完整标准化后与 Standardize 相同
+	std := c.StandardizeLocations()
+	if std.OuterRing.Speed != 7 || std.InnerRing.Speed != -8 {
+		t.Errorf("unexpected speeds: %d, %d (expected: 7, -8)", std.OuterRing.Speed, std.InnerRing.Speed)
+	}
+	if !std.Equal(c.Standardize()) {
+		t.Errorf("unexpected result: %s (expected to equal %s)", std.RawSpeedString(), c.Standardize().String())
+	}
+}
+
+// TestCompassEqual 测试 Compass.Equal 和 Compass.SamePositions 方法
+func TestCompassEqual(t *testing.T) {
+	a := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterInnerRingGroup, MiddleInnerRingGroup},
+	}
+	// 标准化后相同
+	b := &Compass{
+		OuterRing:  Ring{Location: 6, Speed: 1},
+		MiddleRing: Ring{Location: -2, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{MiddleInnerRingGroup, OuterInnerRingGroup, OuterInnerRingGroup},
+	}
+	// 仅位置相同
+	c := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: -1},
+		MiddleRing: Ring{Location: 4, Speed: 3},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterRingGroup},
+	}
+
+	if !a.Equal(b) || !a.SamePositions(b) {
+		t.Errorf("expected %s to equal %s", a, b)
+	}
+	if a.Equal(c) {
+		t.Errorf("expected %s not to equal %s", a, c)
+	}
+	if !a.SamePositions(c) {
+		t.Errorf("expected %s to have the same positions as %s", a, c)
+	}
+}
+
+// TestCompassGroupEffects 测试 Compass.GroupEffects 方法
+func TestCompassGroupEffects(t *testing.T) {
+	effects := (&Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{
+			OuterRingGroup,
+			MiddleRingGroup,
+			InnerRingGroup,
+			OuterMiddleRingGroup,
+			OuterInnerRingGroup,
+			MiddleInnerRingGroup,
+		},
+	}).GroupEffects()
+
+	expectedOuter := [3]int{1, 0, 0}
+	if effects[OuterRingGroup] != expectedOuter {
+		t.Errorf("unexpected effect of %s: %v (expected: %v)", OuterRingGroup, effects[OuterRingGroup], expectedOuter)
+	}
+	// 组合圈分组的位移等于其包含的各单个圈的位移之和
+	for _, combo := range [][3]RingGroup{
+		{OuterMiddleRingGroup, OuterRingGroup, MiddleRingGroup},
+		{OuterInnerRingGroup, OuterRingGroup, InnerRingGroup},
+		{MiddleInnerRingGroup, MiddleRingGroup, InnerRingGroup},
+	} {
+		a, b := effects[combo[1]], effects[combo[2]]
+		expected := [3]int{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+		if effects[combo[0]] != expected {
+			t.Errorf("unexpected effect of %s: %v (expected: %v)", combo[0], effects[combo[0]], expected)
+		}
+	}
+}
+
+// TestCompassArrowString 测试 Compass.ArrowString 方法
+func TestCompassArrowString(t *testing.T) {
+	ret := (&Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 3, Speed: 0},
+		RingGroups: []RingGroup{
+			OuterInnerRingGroup,
+			MiddleInnerRingGroup,
+		},
+	}).ArrowString()
+	expectedRet := "0◎↻1,4↺4,3·/mi,oi"
+
+	if ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+}
+
+// TestCompassRotate 测试 Compass.Rotate 方法
+func TestCompassRotate(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 5, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 4, Speed: 2},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, InnerRingGroup},
+	}
+	if err := c.Rotate(OuterMiddleRingGroup); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := c.Rotate(InnerRingGroup); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !c.IsSolved() {
+		t.Errorf("unexpected result: %s (expected to be solved)", c)
+	}
+	if err := c.Rotate(OuterRingGroup); err == nil {
+		t.Errorf("expected error rotating unsupported ring group")
+	}
+}
+
+// TestCompassApplySteps 测试 Compass.ApplySteps 方法
+func TestCompassApplySteps(t *testing.T) {
+	c := &Compass{
+		OuterRing:       Ring{Location: 0, Speed: 1},
+		MiddleRing:      Ring{Location: 4, Speed: -4},
+		InnerRing:       Ring{Location: 0, Speed: 2},
+		RingGroups:      []RingGroup{MiddleInnerRingGroup, OuterInnerRingGroup, OuterMiddleRingGroup},
+		CompositeGroups: [][]RingGroup{{OuterMiddleRingGroup, MiddleInnerRingGroup}},
+	}
+	if err := c.ApplySteps(Steps{{RingGroup: MiddleInnerRingGroup, Count: 2}, {RingGroup: OuterInnerRingGroup, Count: 4}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expected := Compass{
+		OuterRing:  Ring{Location: 4, Speed: 1},
+		MiddleRing: Ring{Location: 2, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+	}
+	if !c.SamePositions(&expected) {
+		t.Errorf("unexpected result: %s (expected: %s)", c, &expected)
+	}
+	if err := c.ApplySteps(Steps{{RingGroup: OuterMiddleRingGroup, Count: 2}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if !c.IsSolved() {
+		t.Errorf("unexpected result: %s (expected to be solved)", c)
+	}
+
+	// 复合圈分组依次转动其中各圈分组
+	if err := c.ApplySteps(Steps{{Composite: []RingGroup{MiddleInnerRingGroup, OuterMiddleRingGroup}, Count: 1}}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expected = Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 2, Speed: 2},
+	}
+	if !c.SamePositions(&expected) {
+		t.Errorf("unexpected result: %s (expected: %s)", c, &expected)
+	}
+
+	// 有不支持的圈分组时罗盘保持原样
+	if err := c.ApplySteps(Steps{{RingGroup: OuterMiddleRingGroup, Count: 1}, {RingGroup: OuterRingGroup, Count: 1}}); err == nil {
+		t.Errorf("expected error applying unsupported ring group")
+	}
+	if !c.SamePositions(&expected) {
+		t.Errorf("unexpected result: %s (expected to be unchanged)", c)
+	}
+}
+
+// TestCompassOffTargetRings 测试 Compass.OffTargetRings 方法
+func TestCompassOffTargetRings(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 4, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: -4},
+		InnerRing:  Ring{Location: 3, Speed: 2},
+	}
+	ret := c.OffTargetRings()
+	expected := []RingOffset{
+		{Ring: OuterRingGroup, Location: 4, Distance: 2},
+		{Ring: InnerRingGroup, Location: 3, Distance: 3},
+	}
+	if !reflect.DeepEqual(ret, expected) {
+		t.Errorf("unexpected result: %v (expected: %v)", ret, expected)
+	}
+
+	c.OuterRing.Location, c.InnerRing.Location = 0, 0
+	if ret := c.OffTargetRings(); ret != nil {
+		t.Errorf("unexpected result: %v (expected: nil)", ret)
+	}
+}
+
+// TestSpeedFromNotches 测试 SpeedFromNotches 和 NotchesFromSpeed 的相互转换
+func TestSpeedFromNotches(t *testing.T) {
+	for _, tc := range []struct {
+		notches   int
+		clockwise bool
+		speed     int
+	}{
+		{notches: 1, clockwise: true, speed: 1},
+		{notches: 2, clockwise: false, speed: -2},
+		{notches: 4, clockwise: false, speed: -4},
+		{notches: 0, clockwise: true, speed: 0},
+	} {
+		speed := SpeedFromNotches(tc.notches, tc.clockwise)
+		if speed != tc.speed {
+			t.Errorf("unexpected speed of %d notches (clockwise: %t): %d (expected: %d)", tc.notches, tc.clockwise, speed, tc.speed)
+		}
+		notches, clockwise := NotchesFromSpeed(speed)
+		if notches != tc.notches || clockwise != tc.clockwise {
+			t.Errorf(
+				"unexpected notches of speed %d: %d (clockwise: %t) (expected: %d (clockwise: %t))",
+				speed, notches, clockwise, tc.notches, tc.clockwise,
+			)
+		}
+	}
+}
+
+// TestCompassIsStandardized 测试 Compass.IsStandardized 方法
+func TestCompassIsStandardized(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 7, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -10},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterInnerRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+	}
+	if c.IsStandardized() {
+		t.Errorf("expected %#v not to be standardized", c)
+	}
+	if std := c.Standardize(); !std.IsStandardized() {
+		t.Errorf("expected %#v to be standardized", std)
+	}
+}
+
+// BenchmarkStandardizedCompass 对比求解已标准化与未标准化的罗盘时的开销
+func BenchmarkStandardizedCompass(b *testing.B) {
+	solver, err := NewMinCostSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		b.Fatalf("new min cost solver error: %s", err)
+	}
+	var standardized, unstandardized []Compass
+	for hash := 0; hash < 216; hash++ {
+		c := Compass{
+			OuterRing:  Ring{Location: hash / 36, Speed: 1},
+			MiddleRing: Ring{Location: hash / 6 % 6, Speed: -4},
+			InnerRing:  Ring{Location: hash % 6, Speed: 2},
+			RingGroups: []RingGroup{OuterMiddleRingGroup, OuterInnerRingGroup, MiddleInnerRingGroup},
+		}
+		unstandardized = append(unstandardized, c)
+		standardized = append(standardized, *c.Standardize())
+	}
+
+	for _, bc := range []struct {
+		name      string
+		compasses []Compass
+	}{
+		{name: "Standardized", compasses: standardized},
+		{name: "Unstandardized", compasses: unstandardized},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				c := bc.compasses[i%len(bc.compasses)]
+				_, _ = solver.Solve(context.Background(), c)
+				_ = c.String()
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package compasstest 提供测试引航罗盘求解器时使用的辅助函数
+package compasstest
+
+import (
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// AssertMinimal 断言 steps 是罗盘 start 的一个转动次数最少的解法，不是时通过 t 报告错误并返回 false
+// 最少转动次数由 Compass.DistanceTo 对所有状态的广度优先搜索给出，与默认求解器解法的总转动次数相同，
+// 但不依赖任何求解器，因此可以发现求解器的优化意外返回了非最优解法的问题
+func AssertMinimal(t testing.TB, start *compass.Compass, steps compass.Steps) bool {
+	t.Helper()
+	if start == nil {
+		t.Errorf("unexpected nil compass")
+		return false
+	}
+	ok, err := compass.CheckSolution(*start, steps)
+	if err != nil {
+		t.Errorf("check solution %s of %s error: %s", steps, start, err)
+		return false
+	}
+	if !ok {
+		t.Errorf("solution %s does not solve %s", steps, start)
+		return false
+	}
+	minimal := start.DistanceTo(&compass.Compass{})
+	if moves := steps.TotalCount(); moves != minimal {
+		t.Errorf("solution %s of %s is not minimal: %d moves (expected: %d)", steps, start, moves, minimal)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+package compasstest
+
+import (
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// recorder 记录错误的 testing.TB
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(_ string, _ ...any) {
+	r.failed = true
+}
+
+// TestAssertMinimal 测试 AssertMinimal
+func TestAssertMinimal(t *testing.T) {
+	start, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	cases := []struct {
+		steps       string
+		expectedRet bool
+	}{
+		{steps: "mi2,oi4,om2", expectedRet: true},
+		{steps: "mi2,oi1,om5", expectedRet: true},
+		// 能解开但不是最少的
+		{steps: "mi2,oi4,om2,mi6", expectedRet: false},
+		// 不能解开
+		{steps: "mi2,oi4", expectedRet: false},
+	}
+	for _, tc := range cases {
+		steps, err := compass.ParseSteps(tc.steps)
+		if err != nil {
+			t.Fatalf("parse steps error: %s", err)
+		}
+		r := &recorder{TB: t}
+		ret := AssertMinimal(r, &start, steps)
+		if ret != tc.expectedRet || r.failed == ret {
+			t.Errorf("unexpected result of %s: %t (expected: %t)", tc.steps, ret, tc.expectedRet)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package compass
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IsCompositeGroupSupported 判断指定复合圈分组是否是当前罗盘支持的
+// 复合圈分组中圈分组的顺序不影响结果
+func (compass *Compass) IsCompositeGroupSupported(composite []RingGroup) bool {
+	if compass == nil {
+		return false
+	}
+	key := compositeKey(composite)
+	for _, supported := range compass.CompositeGroups {
+		if compositeKey(supported) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// moves 返回当前罗盘所有可能的单次转动，包括各圈分组及复合圈分组，转动次数均为 1
+func (compass *Compass) moves() []Step {
+	std := compass.standardized()
+	moves := make([]Step, 0, len(std.RingGroups)+len(std.CompositeGroups))
+	for _, rg := range std.RingGroups {
+		moves = append(moves, Step{RingGroup: rg, Count: 1})
+	}
+	for _, composite := range std.CompositeGroups {
+		moves = append(moves, Step{Composite: composite, Count: 1})
+	}
+	return moves
+}
+
+// validateCompositeGroups 校验复合圈分组
+// 除了其中的圈分组必须是当前罗盘支持的，还检查重复或冗余的定义：
+// 复合圈分组不能重复包含同一个圈分组，不能只包含一个圈分组，不同的复合圈分组也不能相同（与顺序无关）
+func (compass *Compass) validateCompositeGroups() error {
+	seen := make(map[string]int, len(compass.CompositeGroups))
+	for i, composite := range compass.CompositeGroups {
+		if len(composite) == 0 {
+			return fmt.Errorf("%w: empty composite group at index %d", ErrInvalidCompass, i)
+		}
+		if len(composite) == 1 {
+			return fmt.Errorf(
+				"%w: composite group at index %d contains only one ring group %s (use the ring group directly)",
+				ErrInvalidCompass, i, composite[0].Name(),
+			)
+		}
+		members := make(map[RingGroup]bool, len(composite))
+		for _, rg := range composite {
+			if members[rg] {
+				return fmt.Errorf(
+					"%w: composite group at index %d contains ring group %s more than once: (%s)",
+					ErrInvalidCompass, i, rg.Name(), compositeKey(composite),
+				)
+			}
+			members[rg] = true
+		}
+		key := compositeKey(composite)
+		if j, ok := seen[key]; ok {
+			return fmt.Errorf("%w: composite group at index %d duplicates the one at index %d: (%s)", ErrInvalidCompass, i, j, key)
+		}
+		seen[key] = i
+		for _, rg := range composite {
+			if !compass.IsRingGroupSupported(rg) {
+				return fmt.Errorf(
+					"%w: composite group at index %d contains ring group not supported by compass: %s (must be one of %v)",
+					ErrInvalidCompass, i, rg.Name(), compass.RingGroups,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// standardizeCompositeGroups 标准化复合圈分组
+// 各复合圈分组内的圈分组升序排列，复合圈分组之间按 compositeKey 升序排列并去重
+func standardizeCompositeGroups(composites [][]RingGroup) [][]RingGroup {
+	if len(composites) == 0 {
+		return nil
+	}
+	sorted := make([][]RingGroup, len(composites))
+	for i, composite := range composites {
+		sorted[i] = sortedRingGroups(composite)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return compositeKey(sorted[i]) < compositeKey(sorted[j])
+	})
+	var ret [][]RingGroup
+	for _, composite := range sorted {
+		if len(ret) > 0 && compositeKey(ret[len(ret)-1]) == compositeKey(composite) {
+			continue
+		}
+		ret = append(ret, composite)
+	}
+	return ret
+}
+
+// isCompositeGroupsStandardized 判断复合圈分组是否已经是标准化的形式
+func isCompositeGroupsStandardized(composites [][]RingGroup) bool {
+	for i, composite := range composites {
+		for j := 1; j < len(composite); j++ {
+			if composite[j-1] > composite[j] {
+				return false
+			}
+		}
+		if i > 0 && compositeKey(composites[i-1]) >= compositeKey(composite) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedRingGroups 返回升序排列的圈分组拷贝，不去重
+func sortedRingGroups(rgs []RingGroup) []RingGroup {
+	ret := make([]RingGroup, len(rgs))
+	copy(ret, rgs)
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+	return ret
+}
+
+// compositeKey 返回复合圈分组的字符串表示，与其中圈分组的顺序无关
+// 其中圈分组的排序与 Compass.String 一致，比如 {OuterRingGroup, MiddleInnerRingGroup} 表示为 "mi+o"
+func compositeKey(composite []RingGroup) string {
+	sorted := sortedRingGroups(composite)
+	names := make([]string, len(sorted))
+	for i, rg := range sorted {
+		names[i] = rg.ShortName()
+	}
+	return strings.Join(names, "+")
+}
@@ -0,0 +1,88 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestCompositeGroups 测试复合圈分组
+func TestCompositeGroups(t *testing.T) {
+	c, err := ParseCompass("5+1,5+1,0+1/o,m,(o + M)")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	expectedStr := "5+1,5+1,0+1/m,o,(m+o)"
+	if c.String() != expectedStr {
+		t.Errorf("unexpected result: %#v (expected: %#v)", c.String(), expectedStr)
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+
+	// 复合圈分组作为一次转动
+	for _, newSolver := range []func(SolverOptions) (Solver, error){NewDefaultSolver, NewMinCostSolver} {
+		solver, err := newSolver(SolverOptions{Logger: logr.Discard()})
+		if err != nil {
+			t.Errorf("new solver error: %s", err)
+			return
+		}
+		ret, err := solver.Solve(context.Background(), c)
+		if err != nil {
+			t.Errorf("compass solve error: %s", err)
+			return
+		}
+		expectedRet := "(m+o)1"
+		if ret.String() != expectedRet {
+			t.Errorf("unexpected result: %#v (expected: %#v)", ret.String(), expectedRet)
+		}
+		if ok, err := CheckSolution(c, ret); !ok || err != nil {
+			t.Errorf("unexpected check result of %s: %t, %v", ret, ok, err)
+		}
+	}
+
+	// 复合圈分组中的圈分组必须是罗盘支持的
+	c.CompositeGroups = append(c.CompositeGroups, []RingGroup{OuterRingGroup, InnerRingGroup})
+	if err := c.Validate(); err == nil {
+		t.Errorf("expected validation error of %s", c.String())
+	}
+}
+
+// TestCompositeGroupsValidate 测试校验重复或冗余的复合圈分组
+func TestCompositeGroupsValidate(t *testing.T) {
+	rgs := []RingGroup{OuterRingGroup, MiddleRingGroup, InnerRingGroup}
+	cases := []struct {
+		composites  [][]RingGroup
+		expectedErr string
+	}{
+		{composites: [][]RingGroup{{OuterRingGroup, MiddleRingGroup}, {MiddleRingGroup, InnerRingGroup}}},
+		{
+			composites:  [][]RingGroup{{OuterRingGroup, MiddleRingGroup, OuterRingGroup}},
+			expectedErr: "invalid compass: composite group at index 0 contains ring group Outer more than once: (m+o+o)",
+		},
+		{
+			composites:  [][]RingGroup{{OuterRingGroup, MiddleRingGroup}, {InnerRingGroup, OuterRingGroup}, {MiddleRingGroup, OuterRingGroup}},
+			expectedErr: "invalid compass: composite group at index 2 duplicates the one at index 0: (m+o)",
+		},
+		{
+			composites:  [][]RingGroup{{InnerRingGroup}},
+			expectedErr: "invalid compass: composite group at index 0 contains only one ring group Inner (use the ring group directly)",
+		},
+	}
+	for _, tc := range cases {
+		c := Compass{RingGroups: rgs, CompositeGroups: tc.composites}
+		err := c.Validate()
+		if tc.expectedErr == "" {
+			if err != nil {
+				t.Errorf("unexpected error of %v: %s", tc.composites, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrInvalidCompass) || err.Error() != tc.expectedErr {
+			t.Errorf("unexpected error of %v: %v (expected: %s)", tc.composites, err, tc.expectedErr)
+		}
+	}
+}
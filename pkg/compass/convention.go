@@ -0,0 +1,99 @@
+package compass
+
+import (
+	"fmt"
+)
+
+// 本项目统一使用的约定（即 Compass 和 Ring 字段文档中描述的约定）：
+// 位置是指针从目标位置（罗盘正左方向）沿顺时针方向旋转到当前位置的刻度数，顺时针旋转的速度为正。
+// 如果记录罗盘时把逆时针当作正方向，位置和旋转速度的符号会同时相反，可以用 MirrorConvention 转换。
+
+// AbsSpeedForm 返回旋转速度的规范形式，即把各圈的旋转速度换算为 0-5 内等效的顺时针速度后的罗盘
+// 每次旋转 -4 与每次旋转 +2 的效果完全相同，因此两个罗盘的解法可以直接通用。
+// 返回值同时是标准化的，可以使用 Equal 判断两种记录是否是同一个谜题
+func (compass *Compass) AbsSpeedForm() *Compass {
+	if compass == nil {
+		return nil
+	}
+	ret := compass.Standardize()
+	for _, r := range []*Ring{&ret.OuterRing, &ret.MiddleRing, &ret.InnerRing} {
+		r.Speed = (r.Speed%6 + 6) % 6
+	}
+	for rg, effect := range ret.GroupEffect {
+		for i := range effect {
+			effect[i] = (effect[i]%6 + 6) % 6
+		}
+		ret.GroupEffect[rg] = effect
+	}
+	return ret
+}
+
+// MirrorConvention 返回以相反的正方向记录的同一个罗盘，即各圈位置和旋转速度都取相反数
+// 用于把以逆时针为正方向记录的罗盘转为本项目的约定，反之亦然，两次转换后得到原罗盘
+func (compass *Compass) MirrorConvention() *Compass {
+	if compass == nil {
+		return nil
+	}
+	ret := compass.Clone()
+	for _, r := range []*Ring{&ret.OuterRing, &ret.MiddleRing, &ret.InnerRing} {
+		r.Location = ((-r.Location)%6 + 6) % 6
+		r.Speed = -r.Speed
+	}
+	for rg, effect := range ret.GroupEffect {
+		ret.GroupEffect[rg] = [3]int{-effect[0], -effect[1], -effect[2]}
+	}
+	return ret
+}
+
+// MirrorSpeeds 返回各圈旋转速度都取相反数、位置不变的罗盘，即镜像谜题
+// 与 MirrorConvention 不同，镜像谜题通常不是同一个谜题，但两者的解法一一对应，参见 MirrorSolution 。
+// 两份记录只有旋转速度的符号全部相反时（比如记录者用了相反的旋转方向），可以用 a.MirrorSpeeds().Equal(b) 判断。
+// 对返回值再次调用 MirrorSpeeds 得到原罗盘
+func (compass *Compass) MirrorSpeeds() *Compass {
+	if compass == nil {
+		return nil
+	}
+	ret := compass.Clone()
+	for _, r := range []*Ring{&ret.OuterRing, &ret.MiddleRing, &ret.InnerRing} {
+		r.Speed = -r.Speed
+	}
+	for rg, effect := range ret.GroupEffect {
+		ret.GroupEffect[rg] = [3]int{-effect[0], -effect[1], -effect[2]}
+	}
+	return ret
+}
+
+// MirrorSolution 把罗盘的解法转为其镜像谜题（参见 Compass.MirrorSpeeds ）的解法，反之亦然
+// 转动 k 次的位移在镜像谜题中与转动 -k 次相同，且各圈分组转动 6 次回到原处，
+// 因此把各圈分组（及复合圈分组）的总转动次数 k 换为 (6-k%6)%6 即可，总转动次数为 0 的去掉。
+// 返回的解法是标准化的，但不一定是镜像谜题总转动次数最少的解法
+func MirrorSolution(solution Steps) Steps {
+	var ret Steps
+	for _, step := range solution.Standardize() {
+		if count := (6 - step.Count%6) % 6; count > 0 {
+			step.Count = count
+			ret = append(ret, step)
+		}
+	}
+	return ret
+}
+
+// TranslateSolution 把针对罗盘 from 求得的解法转为罗盘 to 的解法
+// from 和 to 必须是同一个谜题在不同约定下的记录，即 AbsSpeedForm 相同，或其中一个是另一个的 MirrorConvention 。
+// 转动次数只与谜题本身有关，与记录时的约定无关，因此返回的解法与原解法相同；
+// 两个罗盘不是同一个谜题时返回错误，这通常说明只有旋转速度或只有位置的符号被记反了
+func TranslateSolution(solution Steps, from, to *Compass) (Steps, error) {
+	if from == nil || to == nil {
+		return nil, fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	target := to.AbsSpeedForm()
+	if !from.AbsSpeedForm().Equal(target) && !from.MirrorConvention().AbsSpeedForm().Equal(target) {
+		return nil, fmt.Errorf(
+			"compass %s is not the same puzzle as %s under any speed sign convention",
+			to.String(), from.String(),
+		)
+	}
+	ret := make(Steps, len(solution))
+	copy(ret, solution)
+	return ret, nil
+}
@@ -0,0 +1,140 @@
+package compass
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCompassAbsSpeedForm 测试 Compass.AbsSpeedForm 方法
+func TestCompassAbsSpeedForm(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: -8},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+	}
+	expectedRet := "0+1,4+2,0+4/mi,oi,om"
+	ret := c.AbsSpeedForm()
+	if ret.String() != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", ret, expectedRet)
+	}
+	// 规范形式不改变解法
+	solution := Steps{
+		{RingGroup: MiddleInnerRingGroup, Count: 2},
+		{RingGroup: OuterInnerRingGroup, Count: 4},
+		{RingGroup: OuterMiddleRingGroup, Count: 2},
+	}
+	for _, comp := range []*Compass{c, ret} {
+		if ok, err := CheckSolution(*comp, solution); err != nil || !ok {
+			t.Errorf("unexpected result of %s: %t, %v (expected: true)", comp, ok, err)
+		}
+	}
+}
+
+// TestCompassMirrorConvention 测试 Compass.MirrorConvention 方法
+func TestCompassMirrorConvention(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 1, Speed: 2},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+	}
+	expectedRet := "0-1,2+4,5-2/mi,oi,om"
+	ret := c.MirrorConvention()
+	if ret.String() != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", ret, expectedRet)
+	}
+	if !ret.MirrorConvention().Equal(c) {
+		t.Errorf("unexpected result of mirroring twice: %s (expected: %s)", ret.MirrorConvention(), c)
+	}
+}
+
+// TestCompassMirrorSpeeds 测试 Compass.MirrorSpeeds 方法及 MirrorSolution
+func TestCompassMirrorSpeeds(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+	}
+	expectedRet := "0-1,4+4,0-2/mi,oi,om"
+	mirror := c.MirrorSpeeds()
+	if mirror.String() != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", mirror, expectedRet)
+	}
+	if !mirror.MirrorSpeeds().Equal(c) {
+		t.Errorf("unexpected result of mirroring twice: %s (expected: %s)", mirror.MirrorSpeeds(), c)
+	}
+
+	// 解法映射为镜像谜题的解法，再映射一次得到原解法
+	solution := Steps{
+		{RingGroup: OuterInnerRingGroup, Count: 4},
+		{RingGroup: MiddleInnerRingGroup, Count: 2},
+		{RingGroup: OuterMiddleRingGroup, Count: 2},
+	}
+	expectedMirrored := "mi4,oi2,om4"
+	mirrored := MirrorSolution(solution)
+	if mirrored.String() != expectedMirrored {
+		t.Errorf("unexpected result: %s (expected: %s)", mirrored, expectedMirrored)
+	}
+	if ok, err := CheckSolution(*mirror, mirrored); err != nil || !ok {
+		t.Errorf("unexpected result of %s: %t, %v (expected: true)", mirror, ok, err)
+	}
+	if ret := MirrorSolution(mirrored); !reflect.DeepEqual(ret, solution.Standardize()) {
+		t.Errorf("unexpected result of mirroring twice: %s (expected: %s)", ret, solution.Standardize())
+	}
+
+	// 转动 6 次的圈分组去掉
+	if ret := MirrorSolution(Steps{{RingGroup: OuterRingGroup, Count: 6}, {RingGroup: InnerRingGroup, Count: 7}}); ret.String() != "i5" {
+		t.Errorf("unexpected result: %s (expected: i5)", ret)
+	}
+}
+
+// TestTranslateSolution 测试 TranslateSolution
+func TestTranslateSolution(t *testing.T) {
+	from := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+	}
+	solution := Steps{
+		{RingGroup: MiddleInnerRingGroup, Count: 2},
+		{RingGroup: OuterInnerRingGroup, Count: 4},
+		{RingGroup: OuterMiddleRingGroup, Count: 2},
+	}
+	cases := []struct {
+		to          *Compass
+		expectedErr bool
+	}{
+		{to: from.AbsSpeedForm()},
+		{to: from.MirrorConvention()},
+		{to: from.MirrorConvention().AbsSpeedForm()},
+		// 只有旋转速度的符号被记反了
+		{
+			to: &Compass{
+				OuterRing:  Ring{Location: 0, Speed: -1},
+				MiddleRing: Ring{Location: 4, Speed: 4},
+				InnerRing:  Ring{Location: 0, Speed: -2},
+				RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range cases {
+		ret, err := TranslateSolution(solution, from, tc.to)
+		if (err != nil) != tc.expectedErr {
+			t.Errorf("unexpected error of %s: %v (expected error: %t)", tc.to, err, tc.expectedErr)
+			continue
+		}
+		if tc.expectedErr {
+			continue
+		}
+		if !reflect.DeepEqual(ret, solution) {
+			t.Errorf("unexpected result of %s: %s (expected: %s)", tc.to, ret, solution)
+		}
+		if ok, err := CheckSolution(*tc.to, ret); err != nil || !ok {
+			t.Errorf("unexpected check result of %s: %t, %v (expected: true)", tc.to, ok, err)
+		}
+	}
+}
@@ -2,22 +2,32 @@ package compass
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"sort"
 
 	"github.com/go-logr/logr"
 )
 
 // NewDefaultSolver 创建一个默认引航罗盘求解器
+// 求解器返回总转动次数最少的解法
 func NewDefaultSolver(opts SolverOptions) (Solver, error) {
+	if err := validateGroupLimits(opts.GroupLimits); err != nil {
+		return nil, err
+	}
 	return &defaultSolver{
-		logger: opts.Logger,
+		logger:      opts.Logger,
+		trace:       opts.Trace,
+		groupLimits: opts.GroupLimits,
 	}, nil
 }
 
 // defaultSolver 默认引航罗盘求解器
 type defaultSolver struct {
-	logger logr.Logger
+	logger      logr.Logger
+	trace       io.Writer
+	groupLimits map[RingGroup]int
 }
 
 var _ Solver = &defaultSolver{}
@@ -28,9 +38,42 @@ func (s *defaultSolver) Solve(_ context.Context, compass Compass) (Steps, error)
 	if err := compass.Validate(); err != nil {
 		return nil, fmt.Errorf("compass validation error: %w", err)
 	}
+	if err := compass.PreSolveCheck(); err != nil {
+		return nil, err
+	}
+
+	// 可以交换转动顺序时直接解方程组，需要记录搜索过程或限制转动次数时仍然搜索
+	if compass.IsCommutative() && s.trace == nil && len(s.groupLimits) == 0 {
+		counts, err := compass.SolveAlgebraic()
+		if errors.Is(err, ErrUnsolvable) {
+			return nil, s.unsolvableError(compass)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var solution Steps
+		for rg, count := range counts {
+			solution = append(solution, Step{RingGroup: rg, Count: count})
+		}
+		return solution.Standardize(), nil
+	}
+
+	// 记录搜索过程
+	var tracer *searchTracer
+	if s.trace != nil {
+		tracer = newSearchTracer(s.trace)
+		defer func() {
+			if err := tracer.close(); err != nil {
+				s.logger.Error(err, "write search trace error")
+			}
+		}()
+	}
 
 	// 尝试所有可能的解法
 	for _, solution := range s.getPossibleSolutions(compass) {
+		if tracer != nil {
+			tracer.traceSolution(compass, solution)
+		}
 		if ok, _ := CheckSolution(compass, solution); ok {
 			// 撞到了一个有效的解法
 			return solution.Standardize(), nil
@@ -38,47 +81,77 @@ func (s *defaultSolver) Solve(_ context.Context, compass Compass) (Steps, error)
 		s.logger.V(1).Info(fmt.Sprintf("try solution '%s' failed", solution.String()))
 	}
 
-	return nil, fmt.Errorf("the compass has no solution")
+	return nil, s.unsolvableError(compass)
+}
+
+// unsolvableError 返回找不到解法时的错误
+func (s *defaultSolver) unsolvableError(compass Compass) error {
+	if err := compass.Solvability(); err != nil {
+		return err
+	}
+	if len(s.groupLimits) > 0 {
+		return fmt.Errorf("%w: no solution within the group limits", ErrUnsolvable)
+	}
+	return ErrUnsolvable
 }
 
 // getPossibleSolutions 获取所有可能的解法
 func (s *defaultSolver) getPossibleSolutions(compass Compass) []Steps {
 	var possibleSolutions []Steps
 
-	for _, rg := range compass.RingGroups {
+	for _, move := range compass.moves() {
 		var temp []Steps
-		// 因为转 6 次可以保证任何 rg 都能回到原点
-		// TODO: 其实可以优化成使用 rg 涉及各圈循环周期的最小公约数
+		// 因为转 6 次可以保证任何圈分组（或复合圈分组）都能回到原点
+		// TODO: 其实可以优化成使用涉及各圈循环周期的最小公约数
 		for i := 0; i < 6; i++ {
+			step := move
+			step.Count = i
 			if len(possibleSolutions) == 0 {
-				temp = append(temp, Steps{{
-					RingGroup: rg,
-					Count:     i,
-				}})
+				temp = append(temp, Steps{step})
 				continue
 			}
 			for _, cur := range possibleSolutions {
-				temp = append(temp, append(cur, Step{
-					RingGroup: rg,
-					Count:     i,
-				}))
+				temp = append(temp, append(cur[:len(cur):len(cur)], step))
 			}
 		}
 		possibleSolutions = temp
 	}
 
+	// 过滤掉超出最大转动次数的解法
+	if len(s.groupLimits) > 0 {
+		var filtered []Steps
+		for _, solution := range possibleSolutions {
+			if s.withinGroupLimits(solution) {
+				filtered = append(filtered, solution)
+			}
+		}
+		possibleSolutions = filtered
+	}
+
 	// 按步骤数排序
 	sort.SliceStable(possibleSolutions, func(i, j int) bool {
-		sumI := 0
-		for _, step := range possibleSolutions[i] {
-			sumI += step.Count
-		}
-		sumJ := 0
-		for _, step := range possibleSolutions[j] {
-			sumI += step.Count
-		}
-		return sumI < sumJ
+		return possibleSolutions[i].TotalCount() < possibleSolutions[j].TotalCount()
 	})
 
 	return possibleSolutions
 }
+
+// withinGroupLimits 判断解法中各圈分组的转动次数是否都不超过最大转动次数
+func (s *defaultSolver) withinGroupLimits(solution Steps) bool {
+	for rg, count := range solution.GroupCounts() {
+		if limit, ok := s.groupLimits[rg]; ok && count > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// validateGroupLimits 校验各圈分组的最大转动次数
+func validateGroupLimits(limits map[RingGroup]int) error {
+	for rg, limit := range limits {
+		if limit < 0 {
+			return fmt.Errorf("invalid limit of ring group %s: %d (must not be negative)", rg.Name(), limit)
+		}
+	}
+	return nil
+}
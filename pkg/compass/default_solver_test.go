@@ -1,7 +1,10 @@
 package compass
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -48,3 +51,104 @@ func TestDefaultSolver(t *testing.T) {
 		t.Errorf("unexpected result: %#v (expect to be one of %#v)", ret.String(), expectedRets)
 	}
 }
+
+// TestDefaultSolverTrace 测试默认求解器的搜索过程记录
+func TestDefaultSolverTrace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard(), Trace: buf})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+
+	_, err = solver.Solve(context.Background(), Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup},
+	})
+	if err != nil {
+		t.Errorf("compass solve error: %s", err)
+		return
+	}
+
+	// 校验结果
+	ret := buf.String()
+	for _, expected := range []string{"digraph compass {\n", "  36 [label=\"1,0,0\"];\n", "  36 -> 72 [label=\"o\"];\n", "}\n"} {
+		if !strings.Contains(ret, expected) {
+			t.Errorf("unexpected result: %#v (expected to contain %#v)", ret, expected)
+		}
+	}
+}
+
+// TestDefaultSolverMinimal 测试默认求解器返回转动次数最少的解法
+func TestDefaultSolverMinimal(t *testing.T) {
+	solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+
+	ret, err := solver.Solve(context.Background(), Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 1, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+	})
+	if err != nil {
+		t.Errorf("compass solve error: %s", err)
+		return
+	}
+
+	// 校验结果
+	expectedRet := "om5"
+	if ret.String() != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret.String(), expectedRet)
+	}
+}
+
+// TestDefaultSolverGroupLimits 测试默认求解器的最大转动次数限制
+func TestDefaultSolverGroupLimits(t *testing.T) {
+	c := Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 1, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+	}
+	cases := []struct {
+		limits      map[RingGroup]int
+		expectedRet string
+	}{
+		{limits: nil, expectedRet: "om5"},
+		{limits: map[RingGroup]int{OuterMiddleRingGroup: 3}, expectedRet: "m2,o2,om3"},
+		{limits: map[RingGroup]int{OuterMiddleRingGroup: 0}, expectedRet: "m5,o5"},
+	}
+	for _, tc := range cases {
+		solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard(), GroupLimits: tc.limits})
+		if err != nil {
+			t.Errorf("new default solver error: %s", err)
+			return
+		}
+		ret, err := solver.Solve(context.Background(), c)
+		if err != nil {
+			t.Errorf("compass solve error with limits %v: %s", tc.limits, err)
+			continue
+		}
+		if ret.String() != tc.expectedRet {
+			t.Errorf("unexpected result with limits %v: %#v (expected: %#v)", tc.limits, ret.String(), tc.expectedRet)
+		}
+	}
+
+	// 限制内无解
+	solver, err := NewDefaultSolver(SolverOptions{
+		Logger:      logr.Discard(),
+		GroupLimits: map[RingGroup]int{OuterMiddleRingGroup: 0, OuterRingGroup: 2},
+	})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+	if _, err := solver.Solve(context.Background(), c); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected to be %s)", err, ErrUnsolvable)
+	}
+}
@@ -0,0 +1,59 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+)
+
+// NewDialsSolver 创建一个最少转盘引航罗盘求解器
+// 求解器返回转动的圈分组（及复合圈分组）数最少的解法，数量相同时返回总转动次数最少的，参见 Steps.Dials
+func NewDialsSolver(opts SolverOptions) (Solver, error) {
+	if err := validateGroupLimits(opts.GroupLimits); err != nil {
+		return nil, err
+	}
+	return &dialsSolver{
+		defaultSolver: defaultSolver{
+			logger:      opts.Logger,
+			trace:       opts.Trace,
+			groupLimits: opts.GroupLimits,
+		},
+	}, nil
+}
+
+// dialsSolver 最少转盘引航罗盘求解器
+type dialsSolver struct {
+	defaultSolver
+}
+
+var _ Solver = &dialsSolver{}
+
+// Solve 求解引航罗盘
+func (s *dialsSolver) Solve(_ context.Context, compass Compass) (Steps, error) {
+	// 校验入参
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if err := compass.PreSolveCheck(); err != nil {
+		return nil, err
+	}
+
+	// 可能的解法已按总转动次数排序，转盘数更少时才替换，因此转盘数相同时保留总转动次数最少的
+	var (
+		best  Steps
+		found bool
+	)
+	for _, solution := range s.getPossibleSolutions(compass) {
+		if found && best.Dials() <= 1 {
+			break
+		}
+		if !found || solution.Dials() < best.Dials() {
+			if ok, _ := CheckSolution(compass, solution); ok {
+				best, found = solution, true
+			}
+		}
+	}
+	if !found {
+		return nil, s.unsolvableError(compass)
+	}
+	return best.Standardize(), nil
+}
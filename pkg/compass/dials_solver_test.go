@@ -0,0 +1,52 @@
+package compass
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestDialsSolver 测试最少转盘求解器
+func TestDialsSolver(t *testing.T) {
+	c := Compass{
+		OuterRing:  Ring{Location: 2, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: 2},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+	}
+
+	// 默认求解器给出的解法 o3,om1 转动两个圈分组
+	defaultSolver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+	defaultRet, err := defaultSolver.Solve(context.Background(), c)
+	if err != nil {
+		t.Errorf("compass solve error: %s", err)
+		return
+	}
+
+	// 最少转盘求解器给出的解法只转动一个圈分组，但总转动次数更多
+	solver, err := NewDialsSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new dials solver error: %s", err)
+		return
+	}
+	ret, err := solver.Solve(context.Background(), c)
+	if err != nil {
+		t.Errorf("compass solve error: %s", err)
+		return
+	}
+	expectedRet := "om4"
+	if ret.String() != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", ret, expectedRet)
+	}
+	if ret.Dials() >= defaultRet.Dials() {
+		t.Errorf("unexpected dials: %d (expected less than %d of %s)", ret.Dials(), defaultRet.Dials(), defaultRet)
+	}
+	if ok, _ := CheckSolution(c, ret); !ok {
+		t.Errorf("unexpected result: %s (not a solution)", ret)
+	}
+}
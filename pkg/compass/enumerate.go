@@ -0,0 +1,110 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+)
+
+// EnumeratedCompass 枚举得到的一个可解的罗盘及其解法
+type EnumeratedCompass struct {
+	// 罗盘
+	Compass Compass
+	// 求解器给出的解法
+	Solution Steps
+}
+
+// Enumerate 枚举给定圈分组和旋转速度下所有可解的罗盘
+// 遍历外圈、中圈、内圈初始位置的全部 216 种组合并逐个求解，返回其中可解的罗盘及其解法，
+// 按外圈、中圈、内圈初始位置升序排列。 speeds 依次为外圈、中圈、内圈的旋转速度
+func Enumerate(ctx context.Context, solver Solver, rgs []RingGroup, speeds [3]int) ([]EnumeratedCompass, error) {
+	var ret []EnumeratedCompass
+	for outer := 0; outer < 6; outer++ {
+		for middle := 0; middle < 6; middle++ {
+			for inner := 0; inner < 6; inner++ {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				c := Compass{
+					OuterRing:  Ring{Location: outer, Speed: speeds[0]},
+					MiddleRing: Ring{Location: middle, Speed: speeds[1]},
+					InnerRing:  Ring{Location: inner, Speed: speeds[2]},
+					RingGroups: rgs,
+				}
+				if err := c.Validate(); err != nil {
+					return nil, fmt.Errorf("compass validation error: %w", err)
+				}
+				if c.Solvability() != nil {
+					// 无解，跳过
+					continue
+				}
+				solution, err := solver.Solve(ctx, c)
+				if err != nil {
+					return nil, WrapSolveError(c, err)
+				}
+				ret = append(ret, EnumeratedCompass{Compass: c, Solution: solution})
+			}
+		}
+	}
+	return ret, nil
+}
+
+// SolvedBy 返回给定圈分组和旋转速度下，按 steps 转动后恰好解开的所有罗盘
+// 转动是确定且可逆的，因此这样的罗盘至多只有一个，即目标状态按 steps 反向转动得到的罗盘。
+// steps 中的复合圈分组会加入返回罗盘的 CompositeGroups ；
+// steps 中有不在 rgs 中的圈分组，或圈分组不合法时返回 nil 。 speeds 依次为外圈、中圈、内圈的旋转速度
+func SolvedBy(steps Steps, rgs []RingGroup, speeds [3]int) []*Compass {
+	c := &Compass{
+		OuterRing:  Ring{Location: TargetLocation, Speed: -speeds[0]},
+		MiddleRing: Ring{Location: TargetLocation, Speed: -speeds[1]},
+		InnerRing:  Ring{Location: TargetLocation, Speed: -speeds[2]},
+		RingGroups: rgs,
+	}
+	for _, step := range steps {
+		if step.IsComposite() && !c.IsCompositeGroupSupported(step.Composite) {
+			c.CompositeGroups = append(c.CompositeGroups, step.Composite)
+		}
+	}
+	if err := c.Validate(); err != nil {
+		return nil
+	}
+	// 以相反的旋转速度从目标状态转动，即反向转动
+	if err := c.ApplySteps(steps); err != nil {
+		return nil
+	}
+	c.OuterRing.Speed = speeds[0]
+	c.MiddleRing.Speed = speeds[1]
+	c.InnerRing.Speed = speeds[2]
+	return []*Compass{c.Standardize()}
+}
+
+// HardestPuzzle 返回给定圈分组和旋转速度下最少转动次数最多的可解罗盘及其最少转动次数
+// 遍历外圈、中圈、内圈初始位置的全部 216 种组合，最少转动次数相同时返回初始位置最小的罗盘。
+// 圈分组不合法时返回 nil 和 -1 。 speeds 依次为外圈、中圈、内圈的旋转速度
+func HardestPuzzle(rgs []RingGroup, speeds [3]int) (*Compass, int) {
+	solved := &Compass{
+		OuterRing:  Ring{Location: TargetLocation},
+		MiddleRing: Ring{Location: TargetLocation},
+		InnerRing:  Ring{Location: TargetLocation},
+	}
+	var hardest *Compass
+	maxMoves := -1
+	for outer := 0; outer < 6; outer++ {
+		for middle := 0; middle < 6; middle++ {
+			for inner := 0; inner < 6; inner++ {
+				c := &Compass{
+					OuterRing:  Ring{Location: outer, Speed: speeds[0]},
+					MiddleRing: Ring{Location: middle, Speed: speeds[1]},
+					InnerRing:  Ring{Location: inner, Speed: speeds[2]},
+					RingGroups: rgs,
+				}
+				if c.Validate() != nil {
+					return nil, -1
+				}
+				if moves := c.DistanceTo(solved); moves > maxMoves {
+					hardest, maxMoves = c, moves
+				}
+			}
+		}
+	}
+	return hardest.Standardize(), maxMoves
+}
@@ -0,0 +1,117 @@
+package compass
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestEnumerate 测试 Enumerate
+func TestEnumerate(t *testing.T) {
+	solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+
+	// 只有外圈可以转动，内圈和中圈必须在目标位置
+	ret, err := Enumerate(context.Background(), solver, []RingGroup{OuterRingGroup}, [3]int{1, 1, 1})
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if len(ret) != 6 {
+		t.Errorf("unexpected number of solvable compasses: %d (expected: %d)", len(ret), 6)
+		return
+	}
+	for i, e := range ret {
+		if e.Compass.OuterRing.Location != i || e.Solution.TotalCount() != (6-i)%6 {
+			t.Errorf("unexpected result at index %d: %s => %s", i, e.Compass.String(), e.Solution.String())
+		}
+	}
+}
+
+// TestSolvedBy 测试 SolvedBy
+func TestSolvedBy(t *testing.T) {
+	rgs := []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup}
+	speeds := [3]int{1, -4, 2}
+	cases := []struct {
+		steps       Steps
+		expectedRet []string
+	}{
+		{
+			steps: Steps{
+				{RingGroup: MiddleInnerRingGroup, Count: 2},
+				{RingGroup: OuterInnerRingGroup, Count: 4},
+				{RingGroup: OuterMiddleRingGroup, Count: 2},
+			},
+			expectedRet: []string{"0+1,4-4,0+2/mi,oi,om"},
+		},
+		// 不转动时只有已解开的罗盘
+		{steps: nil, expectedRet: []string{"0+1,0-4,0+2/mi,oi,om"}},
+		// 复合圈分组
+		{
+			steps:       Steps{{Composite: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup}, Count: 1}},
+			expectedRet: []string{"5+1,2-4,4+2/mi,oi,om,(mi+om)"},
+		},
+		// 不支持的圈分组
+		{steps: Steps{{RingGroup: OuterRingGroup, Count: 1}}, expectedRet: nil},
+	}
+	for _, tc := range cases {
+		ret := SolvedBy(tc.steps, rgs, speeds)
+		var strs []string
+		for _, c := range ret {
+			strs = append(strs, c.String())
+			if ok, err := CheckSolution(*c, tc.steps); err != nil || !ok {
+				t.Errorf("unexpected check result of %s with %s: %t, %v (expected: true)", c, tc.steps, ok, err)
+			}
+		}
+		if !reflect.DeepEqual(strs, tc.expectedRet) {
+			t.Errorf("unexpected result of %s: %#v (expected: %#v)", tc.steps, strs, tc.expectedRet)
+		}
+	}
+}
+
+// TestHardestPuzzle 测试 HardestPuzzle
+func TestHardestPuzzle(t *testing.T) {
+	cases := []struct {
+		rgs           []RingGroup
+		speeds        [3]int
+		expectedRet   string
+		expectedMoves int
+	}{
+		// 只能顺时针转动，离目标位置最近的位置最难
+		{
+			rgs:           []RingGroup{OuterRingGroup},
+			speeds:        [3]int{1, 1, 1},
+			expectedRet:   "1+1,0+1,0+1/o",
+			expectedMoves: 5,
+		},
+		{
+			rgs:           []RingGroup{OuterRingGroup, MiddleRingGroup},
+			speeds:        [3]int{1, 1, 1},
+			expectedRet:   "1+1,1+1,0+1/m,o",
+			expectedMoves: 10,
+		},
+		// 转半圈
+		{
+			rgs:           []RingGroup{InnerRingGroup},
+			speeds:        [3]int{1, 1, 3},
+			expectedRet:   "0+1,0+1,3+3/i",
+			expectedMoves: 1,
+		},
+	}
+	for _, tc := range cases {
+		ret, moves := HardestPuzzle(tc.rgs, tc.speeds)
+		if ret.String() != tc.expectedRet || moves != tc.expectedMoves {
+			t.Errorf("unexpected result of %v %v: %s, %d (expected: %s, %d)", tc.rgs, tc.speeds, ret, moves, tc.expectedRet, tc.expectedMoves)
+		}
+	}
+
+	// 圈分组不合法
+	if ret, moves := HardestPuzzle([]RingGroup{0}, [3]int{1, 1, 1}); ret != nil || moves != -1 {
+		t.Errorf("unexpected result of invalid ring groups: %s, %d (expected: nil, -1)", ret, moves)
+	}
+}
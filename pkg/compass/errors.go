@@ -0,0 +1,25 @@
+package compass
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 本包返回的错误会包装以下错误之一，可以使用 errors.Is 判断错误类型
+var (
+	// ErrUnsolvable 罗盘无解
+	ErrUnsolvable = errors.New("the compass has no solution")
+	// ErrInvalidCompass 罗盘不合法，比如圈的位置超出有效范围
+	ErrInvalidCompass = errors.New("invalid compass")
+	// ErrParseFormat 解析的表达式格式错误
+	ErrParseFormat = errors.New("invalid format")
+)
+
+// WrapSolveError 以罗盘的文本表示包装求解该罗盘时的错误，形如 "solve failed for 3+1,0-2,5+0/o,mi: ..."
+// 批量或流式求解时便于定位出错的罗盘； err 为 nil 时返回 nil
+func WrapSolveError(compass Compass, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("solve failed for %s: %w", compass.String(), err)
+}
@@ -0,0 +1,48 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestErrors 测试返回的错误包装了对应的错误类型
+func TestErrors(t *testing.T) {
+	solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Errorf("new default solver error: %s", err)
+		return
+	}
+	solve := func(expr string) error {
+		c, err := ParseCompass(expr)
+		if err != nil {
+			return err
+		}
+		_, err = solver.Solve(context.Background(), c)
+		return err
+	}
+	_, stepErr := ParseSteps("x1")
+	_, shareCodeErr := DecodeSolution("!")
+
+	for _, tc := range []struct {
+		name        string
+		err         error
+		expectedErr error
+	}{
+		{name: "malformed compass", err: solve("0+1,4-4/oi"), expectedErr: ErrParseFormat},
+		{name: "unknown ring group", err: solve("0+1,4-4,0+2/ox"), expectedErr: ErrParseFormat},
+		{name: "malformed step", err: stepErr, expectedErr: ErrParseFormat},
+		{name: "malformed share code", err: shareCodeErr, expectedErr: ErrParseFormat},
+		{name: "invalid location", err: (&Compass{OuterRing: Ring{Location: 6}}).Validate(), expectedErr: ErrInvalidCompass},
+		{name: "uncovered ring", err: solve("1+1,0+1,0+1/m"), expectedErr: ErrUnsolvable},
+		{name: "unreachable ring", err: solve("1+2,0+1,0+1/o"), expectedErr: ErrUnsolvable},
+		{name: "unsolvable", err: solve("1+1,0+1,0+1/om"), expectedErr: ErrUnsolvable},
+		{name: "wrapped unsolvable", err: WrapSolveError(Compass{}, solve("1+1,0+1,0+1/om")), expectedErr: ErrUnsolvable},
+	} {
+		if !errors.Is(tc.err, tc.expectedErr) {
+			t.Errorf("unexpected error of %s: %v (expected to be %s)", tc.name, tc.err, tc.expectedErr)
+		}
+	}
+}
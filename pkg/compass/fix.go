@@ -0,0 +1,114 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+)
+
+// 修正的代价，越小越可能是记录罗盘时的笔误
+const (
+	// 圈的位置差 1 、旋转速度方向记反、漏记圈分组
+	fixCostLikely = 1
+	// 旋转速度大小差 1 、多记圈分组
+	fixCostPossible = 2
+)
+
+// Fix 对罗盘的一处修正，修正后的罗盘有解
+type Fix struct {
+	// Compass 修正后的罗盘
+	Compass *Compass
+	// Change 修正的描述，比如 "outer location 0 -> 1" 、 "middle speed -4 -> +4" 、 "add group oi"
+	Change string
+	// Cost 修正的代价，越小越可能是实际的笔误
+	Cost int
+	// Solution 修正后罗盘总转动次数最少的解法
+	Solution Steps
+}
+
+// SuggestFixes 返回与无解的罗盘只差一处、且有解的罗盘，用于提示记录罗盘时可能的笔误
+// 只差一处即以下修改之一，括号内为代价：
+// 一个圈的位置加减 1 （ 1 ）；一个圈的旋转速度方向相反（ 1 ）；一个圈的旋转速度大小加减 1 ，仍在 1-4 内（ 2 ）；
+// 添加一个圈分组（ 1 ）；去掉一个圈分组（ 2 ，不能去掉复合圈分组中的圈分组，至少保留一个圈分组）。
+// 按代价升序、代价相同时按解法的总转动次数升序返回，至多返回 max 个（ max 不大于 0 时不限）；罗盘本身有解时返回 nil
+func (compass *Compass) SuggestFixes(max int) ([]Fix, error) {
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if compass.Solvability() == nil {
+		return nil, nil
+	}
+	solver := &defaultSolver{logger: logr.Discard()}
+
+	var ret []Fix
+	seen := map[string]bool{compass.String(): true}
+	try := func(c *Compass, cost int, change string) {
+		if c.Validate() != nil || c.Solvability() != nil || seen[c.String()] {
+			return
+		}
+		seen[c.String()] = true
+		solution, err := solver.Solve(context.Background(), *c)
+		if err != nil {
+			return
+		}
+		ret = append(ret, Fix{Compass: c, Change: change, Cost: cost, Solution: solution})
+	}
+
+	std := compass.Standardize()
+	for i, name := range ringNames {
+		ring := func(c *Compass) *Ring {
+			return []*Ring{&c.OuterRing, &c.MiddleRing, &c.InnerRing}[i]
+		}
+		r := *ring(std)
+		for _, delta := range []int{-1, 1} {
+			c := std.Clone()
+			ring(c).Location = (r.Location + delta + 6) % 6
+			try(c, fixCostLikely, fmt.Sprintf("%s location %d -> %d", name, r.Location, ring(c).Location))
+		}
+		if r.Speed != 0 {
+			c := std.Clone()
+			ring(c).Speed = -r.Speed
+			try(c, fixCostLikely, fmt.Sprintf("%s speed %+d -> %+d", name, r.Speed, -r.Speed))
+		}
+		for _, delta := range []int{-1, 1} {
+			speed := r.Speed + delta
+			if speed == 0 || speed < -4 || speed > 4 {
+				continue
+			}
+			c := std.Clone()
+			ring(c).Speed = speed
+			try(c, fixCostPossible, fmt.Sprintf("%s speed %+d -> %+d", name, r.Speed, speed))
+		}
+	}
+	for _, rg := range allRingGroups {
+		c := std.Clone()
+		if !std.IsRingGroupSupported(rg) {
+			c.RingGroups = append(c.RingGroups, rg)
+			try(c, fixCostLikely, "add group "+rg.ShortName())
+			continue
+		}
+		if len(std.RingGroups) <= 1 {
+			continue
+		}
+		c.RingGroups = c.RingGroups[:0]
+		for _, other := range std.RingGroups {
+			if other != rg {
+				c.RingGroups = append(c.RingGroups, other)
+			}
+		}
+		try(c, fixCostPossible, "remove group "+rg.ShortName())
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Cost != ret[j].Cost {
+			return ret[i].Cost < ret[j].Cost
+		}
+		return ret[i].Solution.TotalCount() < ret[j].Solution.TotalCount()
+	})
+	if max > 0 && len(ret) > max {
+		ret = ret[:max]
+	}
+	return ret, nil
+}
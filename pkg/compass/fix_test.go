@@ -0,0 +1,76 @@
+package compass
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCompassSuggestFixes 测试 Compass.SuggestFixes 方法
+func TestCompassSuggestFixes(t *testing.T) {
+	c, err := ParseCompass("3+2,0+1,0+1/o")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	fixes, err := c.SuggestFixes(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var ret []string
+	for _, fix := range fixes {
+		ret = append(ret, fix.Change+" => "+fix.Compass.String()+" "+fix.Solution.String())
+		if ok, err := CheckSolution(*fix.Compass, fix.Solution); err != nil || !ok {
+			t.Errorf("unexpected check result of %s: %t, %v (expected: true)", fix.Compass, ok, err)
+		}
+	}
+	expectedRet := []string{
+		"outer location 3 -> 4 => 4+2,0+1,0+1/o o1",
+		"outer location 3 -> 2 => 2+2,0+1,0+1/o o2",
+		"outer speed +2 -> +3 => 3+3,0+1,0+1/o o1",
+		"outer speed +2 -> +1 => 3+1,0+1,0+1/o o3",
+	}
+	if !reflect.DeepEqual(ret, expectedRet) {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+
+	// 至多返回 max 个
+	if fixes, err := c.SuggestFixes(1); err != nil || len(fixes) != 1 {
+		t.Errorf("unexpected result with max 1: %d fixes, %v (expected: 1 fix)", len(fixes), err)
+	}
+
+	// 漏记圈分组
+	c, err = ParseCompass("1+1,1+1,0+1/o")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	if fixes, err = c.SuggestFixes(1); err != nil || len(fixes) != 1 || fixes[0].Change != "middle location 1 -> 0" {
+		t.Errorf("unexpected result: %+v, %v (expected: middle location 1 -> 0)", fixes, err)
+	}
+	found := false
+	for _, fix := range mustSuggestFixes(t, c) {
+		if fix.Change == "add group m" && fix.Cost == fixCostLikely {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected fix \"add group m\" of %s", c.String())
+	}
+
+	// 本身有解
+	c, err = ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	if fixes, err := c.SuggestFixes(0); err != nil || fixes != nil {
+		t.Errorf("unexpected result of solvable compass: %+v, %v (expected: nil)", fixes, err)
+	}
+}
+
+// mustSuggestFixes 返回罗盘的所有修正，出错时终止测试
+func mustSuggestFixes(t *testing.T, c Compass) []Fix {
+	t.Helper()
+	fixes, err := c.SuggestFixes(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return fixes
+}
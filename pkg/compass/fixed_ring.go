@@ -0,0 +1,59 @@
+package compass
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WithFixedRing 返回指定圈无法转动时的罗盘，即去掉所有包含该圈的圈分组，以及包含这些圈分组的复合圈分组
+// ring 必须是单个圈组成的圈分组，即 OuterRingGroup 、 MiddleRingGroup 或 InnerRingGroup 。
+// 该圈不在目标位置时罗盘显然无解，返回包装了 ErrUnsolvable 的错误
+func (compass *Compass) WithFixedRing(ring RingGroup) (*Compass, error) {
+	if compass == nil {
+		return nil, fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	var r Ring
+	switch ring {
+	case OuterRingGroup:
+		r = compass.OuterRing
+	case MiddleRingGroup:
+		r = compass.MiddleRing
+	case InnerRingGroup:
+		r = compass.InnerRing
+	default:
+		return nil, fmt.Errorf("%w: fixed ring must be one of outer, middle and inner: %s", ErrInvalidCompass, ring.Name())
+	}
+	if (r.Location%6+6)%6 != TargetLocation {
+		return nil, fmt.Errorf(
+			"%w: %s ring is fixed at location %d, which is not the target location %d",
+			ErrUnsolvable, strings.ToLower(ring.Name()), r.Location, TargetLocation,
+		)
+	}
+
+	ret := compass.Clone()
+	ret.RingGroups = nil
+	for _, rg := range compass.RingGroups {
+		if rg&ring == 0 {
+			ret.RingGroups = append(ret.RingGroups, rg)
+		}
+	}
+	ret.CompositeGroups = nil
+	for _, composite := range compass.CompositeGroups {
+		usable := true
+		for _, rg := range composite {
+			if rg&ring > 0 {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			ret.CompositeGroups = append(ret.CompositeGroups, composite)
+		}
+	}
+	for rg := range ret.GroupEffect {
+		if rg&ring > 0 {
+			delete(ret.GroupEffect, rg)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,45 @@
+package compass
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCompassWithFixedRing 测试 Compass.WithFixedRing 方法
+func TestCompassWithFixedRing(t *testing.T) {
+	c := &Compass{
+		OuterRing:       Ring{Location: 0, Speed: 1},
+		MiddleRing:      Ring{Location: 4, Speed: -4},
+		InnerRing:       Ring{Location: 3, Speed: 2},
+		RingGroups:      []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup, InnerRingGroup},
+		CompositeGroups: [][]RingGroup{{MiddleInnerRingGroup, InnerRingGroup}, {OuterMiddleRingGroup, InnerRingGroup}},
+		GroupEffect:     map[RingGroup][3]int{OuterInnerRingGroup: {1, 0, 1}, InnerRingGroup: {0, 0, 1}},
+	}
+	ret, err := c.WithFixedRing(OuterRingGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedRet := "0+1,4-4,3+2/i,mi,(i+mi)"
+	if ret.String() != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", ret, expectedRet)
+	}
+	if _, ok := ret.GroupEffect[OuterInnerRingGroup]; ok || len(ret.GroupEffect) != 1 {
+		t.Errorf("unexpected group effect: %v", ret.GroupEffect)
+	}
+	if err := ret.Validate(); err != nil {
+		t.Errorf("unexpected validation error: %s", err)
+	}
+	// 原罗盘不变
+	if len(c.RingGroups) != 4 || len(c.GroupEffect) != 2 {
+		t.Errorf("unexpected modification of original compass: %s", c)
+	}
+
+	// 不在目标位置的圈无法固定
+	if _, err := c.WithFixedRing(InnerRingGroup); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrUnsolvable)
+	}
+	// 不是单个圈
+	if _, err := c.WithFixedRing(OuterMiddleRingGroup); !errors.Is(err, ErrInvalidCompass) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrInvalidCompass)
+	}
+}
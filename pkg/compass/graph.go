@@ -0,0 +1,67 @@
+package compass
+
+// Node 罗盘状态图中的一个状态
+type Node struct {
+	// 状态的编号，参见 Compass.Hash
+	ID int `json:"id"`
+	// 外圈、中圈、内圈的位置
+	Outer  int `json:"outer"`
+	Middle int `json:"middle"`
+	Inner  int `json:"inner"`
+	// 从初始状态转到该状态所需的最少转动次数
+	Depth int `json:"depth"`
+	// 是否是目标状态
+	Solved bool `json:"solved"`
+}
+
+// Edge 罗盘状态图中的一个状态转移，即转动一次圈分组（或复合圈分组）
+type Edge struct {
+	// 转动前后状态的编号
+	From int `json:"from"`
+	To   int `json:"to"`
+	// 转动的圈分组（或复合圈分组）的字符串表示，比如 "om" 或 "(mi+o)"
+	Group string `json:"group"`
+}
+
+// StateGraph 返回从罗盘当前状态出发可以到达的所有状态，以及这些状态间的所有状态转移
+// 状态按与初始状态的距离（最少转动次数）排列，第一个即初始状态；状态转移按起始状态的顺序排列，
+// 同一状态的状态转移按圈分组的标准化顺序排列。状态至多 216 个
+func (compass *Compass) StateGraph() ([]Node, []Edge) {
+	if compass == nil {
+		return nil, nil
+	}
+	moves := compass.moves()
+
+	var depth [216]int
+	for i := range depth {
+		depth[i] = -1
+	}
+	start := compass.Hash()
+	depth[start] = 0
+	var (
+		nodes []Node
+		edges []Edge
+	)
+	queue := []int{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		nodes = append(nodes, Node{
+			ID:     cur,
+			Outer:  cur / 36,
+			Middle: cur / 6 % 6,
+			Inner:  cur % 6,
+			Depth:  depth[cur],
+			Solved: cur == targetHash,
+		})
+		for i := range moves {
+			next := rotateHashStep(compass, cur, &moves[i])
+			edges = append(edges, Edge{From: cur, To: next, Group: moves[i].key()})
+			if depth[next] < 0 {
+				depth[next] = depth[cur] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nodes, edges
+}
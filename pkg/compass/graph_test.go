@@ -0,0 +1,55 @@
+package compass
+
+import (
+	"testing"
+)
+
+// TestCompassStateGraph 测试 Compass.StateGraph 方法
+func TestCompassStateGraph(t *testing.T) {
+	// 外圈可以到达 1 、 3 、 5 ，中圈不转动
+	c := &Compass{
+		OuterRing:  Ring{Location: 1, Speed: 2},
+		MiddleRing: Ring{Location: 2, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup},
+	}
+	nodes, edges := c.StateGraph()
+	expectedNodes := []Node{
+		{ID: 1*36 + 2*6, Outer: 1, Middle: 2, Depth: 0},
+		{ID: 3*36 + 2*6, Outer: 3, Middle: 2, Depth: 1},
+		{ID: 5*36 + 2*6, Outer: 5, Middle: 2, Depth: 2},
+	}
+	expectedEdges := []Edge{
+		{From: 1*36 + 2*6, To: 3*36 + 2*6, Group: "o"},
+		{From: 3*36 + 2*6, To: 5*36 + 2*6, Group: "o"},
+		{From: 5*36 + 2*6, To: 1*36 + 2*6, Group: "o"},
+	}
+	if len(nodes) != len(expectedNodes) || len(edges) != len(expectedEdges) {
+		t.Fatalf("unexpected result: %v, %v (expected: %v, %v)", nodes, edges, expectedNodes, expectedEdges)
+	}
+	for i := range nodes {
+		if nodes[i] != expectedNodes[i] {
+			t.Errorf("unexpected node %d: %+v (expected: %+v)", i, nodes[i], expectedNodes[i])
+		}
+	}
+	for i := range edges {
+		if edges[i] != expectedEdges[i] {
+			t.Errorf("unexpected edge %d: %+v (expected: %+v)", i, edges[i], expectedEdges[i])
+		}
+	}
+
+	// 可以到达目标状态时，其深度即求解所需的最少转动次数
+	parsed, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	c = &parsed
+	nodes, edges = c.StateGraph()
+	if len(edges) != 3*len(nodes) {
+		t.Errorf("unexpected edges: %d (expected: %d)", len(edges), 3*len(nodes))
+	}
+	for _, n := range nodes {
+		if n.Solved && n.Depth != c.DistanceTo(&Compass{}) {
+			t.Errorf("unexpected depth of the solved state: %d (expected: %d)", n.Depth, c.DistanceTo(&Compass{}))
+		}
+	}
+}
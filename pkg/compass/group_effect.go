@@ -0,0 +1,83 @@
+package compass
+
+import (
+	"fmt"
+)
+
+// groupEffect 返回圈分组转动一次时外圈、中圈、内圈的位移
+// GroupEffect 中有该圈分组时以其为准，否则为圈分组包含的圈的旋转速度，不包含的圈为 0
+func (compass *Compass) groupEffect(rg RingGroup) [3]int {
+	if effect, ok := compass.GroupEffect[rg]; ok {
+		return effect
+	}
+	var effect [3]int
+	if rg&OuterRingGroup > 0 {
+		effect[0] = compass.OuterRing.Speed
+	}
+	if rg&MiddleRingGroup > 0 {
+		effect[1] = compass.MiddleRing.Speed
+	}
+	if rg&InnerRingGroup > 0 {
+		effect[2] = compass.InnerRing.Speed
+	}
+	return effect
+}
+
+// validateGroupEffect 校验圈分组位移
+// 其中的圈分组必须是当前罗盘支持的，且不能移动圈分组不包含的圈
+func (compass *Compass) validateGroupEffect() error {
+	for rg, effect := range compass.GroupEffect {
+		if !compass.IsRingGroupSupported(rg) {
+			return fmt.Errorf(
+				"%w: group effect of ring group not supported by compass: %s (must be one of %v)",
+				ErrInvalidCompass, rg.Name(), compass.RingGroups,
+			)
+		}
+		for i, ring := range []RingGroup{OuterRingGroup, MiddleRingGroup, InnerRingGroup} {
+			if rg&ring == 0 && effect[i]%6 != 0 {
+				return fmt.Errorf(
+					"%w: group effect of ring group %s moves %s ring, which is not in the group: %v",
+					ErrInvalidCompass, rg.Name(), ring.Name(), effect,
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// standardizeGroupEffect 标准化圈分组位移，即把各位移换算到 -5 到 5 之间
+func standardizeGroupEffect(groupEffect map[RingGroup][3]int) map[RingGroup][3]int {
+	if groupEffect == nil {
+		return nil
+	}
+	ret := make(map[RingGroup][3]int, len(groupEffect))
+	for rg, effect := range groupEffect {
+		for i := range effect {
+			effect[i] %= 6
+		}
+		ret[rg] = effect
+	}
+	return ret
+}
+
+// isGroupEffectStandardized 判断圈分组位移是否已经是标准化的形式
+func isGroupEffectStandardized(groupEffect map[RingGroup][3]int) bool {
+	for _, effect := range groupEffect {
+		for _, d := range effect {
+			if d%6 != d {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// groupEffectEqual 判断两个罗盘的各圈分组位移是否相同
+func groupEffectEqual(a, b *Compass) bool {
+	for _, rg := range a.RingGroups {
+		if a.groupEffect(rg) != b.groupEffect(rg) {
+			return false
+		}
+	}
+	return true
+}
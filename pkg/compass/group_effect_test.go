@@ -0,0 +1,126 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// newGroupEffectCompass 返回一个中圈在不同圈分组中旋转速度不同的罗盘
+// 中圈随外圈一起转动时每次转 1 格，随内圈一起转动时每次转 2 格
+func newGroupEffectCompass() Compass {
+	return Compass{
+		OuterRing:  Ring{Location: 3, Speed: 1},
+		MiddleRing: Ring{Location: 5, Speed: 2},
+		InnerRing:  Ring{Location: 4, Speed: 1},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup},
+		GroupEffect: map[RingGroup][3]int{
+			OuterMiddleRingGroup: {1, 1, 0},
+		},
+	}
+}
+
+// TestCompassGroupEffectValidate 测试 Compass.Validate 校验 GroupEffect
+func TestCompassGroupEffectValidate(t *testing.T) {
+	valid := newGroupEffectCompass()
+	if err := valid.Validate(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// 不支持的圈分组
+	unsupported := newGroupEffectCompass()
+	unsupported.GroupEffect[OuterRingGroup] = [3]int{1, 0, 0}
+	// 移动了圈分组不包含的圈
+	outside := newGroupEffectCompass()
+	outside.GroupEffect[MiddleInnerRingGroup] = [3]int{1, 2, 1}
+	for _, c := range []Compass{unsupported, outside} {
+		if err := c.Validate(); !errors.Is(err, ErrInvalidCompass) {
+			t.Errorf("unexpected error: %v (expected: %s)", err, ErrInvalidCompass)
+		}
+	}
+}
+
+// TestCompassGroupEffectRotate 测试 GroupEffect 优先于各圈的旋转速度
+func TestCompassGroupEffectRotate(t *testing.T) {
+	c := newGroupEffectCompass()
+	if err := c.Rotate(OuterMiddleRingGroup); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := c.Rotate(MiddleInnerRingGroup); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expected := "4+1,2+2,5+1/mi,om"
+	if c.String() != expected {
+		t.Errorf("unexpected result: %s (expected: %s)", c.String(), expected)
+	}
+
+	original := newGroupEffectCompass()
+	effects := original.GroupEffects()
+	if effects[OuterMiddleRingGroup] != [3]int{1, 1, 0} || effects[MiddleInnerRingGroup] != [3]int{0, 2, 1} {
+		t.Errorf("unexpected group effects: %#v", effects)
+	}
+}
+
+// TestCompassGroupEffectSolve 测试求解圈分组位移不同于旋转速度的罗盘
+func TestCompassGroupEffectSolve(t *testing.T) {
+	c := newGroupEffectCompass()
+	// 按各圈的旋转速度，中圈始终在奇数位置，无解
+	withoutOverride := newGroupEffectCompass()
+	withoutOverride.GroupEffect = nil
+	if err := withoutOverride.Solvability(); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected solvability without group effect: %v (expected: %s)", err, ErrUnsolvable)
+	}
+	if err := c.Solvability(); err != nil {
+		t.Errorf("unexpected solvability: %s", err)
+	}
+
+	for name, newSolver := range map[string]func(SolverOptions) (Solver, error){
+		"default":  NewDefaultSolver,
+		"min cost": NewMinCostSolver,
+	} {
+		solver, err := newSolver(SolverOptions{Logger: logr.Discard()})
+		if err != nil {
+			t.Fatalf("new %s solver error: %s", name, err)
+		}
+		solution, err := solver.Solve(context.Background(), c)
+		if err != nil {
+			t.Errorf("unexpected error of %s solver: %s", name, err)
+			continue
+		}
+		expected := "mi2,om3"
+		if solution.String() != expected {
+			t.Errorf("unexpected result of %s solver: %s (expected: %s)", name, solution, expected)
+		}
+		if ok, err := CheckSolution(c, solution); err != nil || !ok {
+			t.Errorf("unexpected check result of %s solver: %t, %v (expected: true)", name, ok, err)
+		}
+		applied := c.Clone()
+		if err := applied.ApplySteps(solution); err != nil || !applied.IsSolved() {
+			t.Errorf("unexpected result of applying %s: %s, %v (expected to be solved)", solution, applied, err)
+		}
+	}
+}
+
+// TestCompassGroupEffectEqual 测试 Compass.Equal 及 Compass.Clone 处理 GroupEffect
+func TestCompassGroupEffectEqual(t *testing.T) {
+	a := newGroupEffectCompass()
+	b := a.Clone()
+	if !a.Equal(b) {
+		t.Errorf("unexpected result: %t (expected: true)", false)
+	}
+	// 深拷贝
+	b.GroupEffect[OuterMiddleRingGroup] = [3]int{1, 7, 0}
+	if a.GroupEffect[OuterMiddleRingGroup] != [3]int{1, 1, 0} {
+		t.Errorf("unexpected group effect after modifying clone: %v", a.GroupEffect[OuterMiddleRingGroup])
+	}
+	// 标准化后等价
+	if !a.Equal(b) {
+		t.Errorf("unexpected result of equivalent group effects: %t (expected: true)", false)
+	}
+	b.GroupEffect[OuterMiddleRingGroup] = [3]int{1, 2, 0}
+	if a.Equal(b) {
+		t.Errorf("unexpected result of different group effects: %t (expected: false)", true)
+	}
+}
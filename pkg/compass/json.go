@@ -0,0 +1,111 @@
+package compass
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ringJSON 罗盘圈的 JSON 表示
+type ringJSON struct {
+	Location int `json:"location"`
+	Speed    int `json:"speed"`
+}
+
+// compassJSON 罗盘的 JSON 表示，圈分组以简写名表示，比如：
+//
+//	{"outer":{"location":0,"speed":1},"middle":{"location":4,"speed":-4},"inner":{"location":0,"speed":2},"groups":["mi","oi","om"]}
+type compassJSON struct {
+	Outer      ringJSON          `json:"outer"`
+	Middle     ringJSON          `json:"middle"`
+	Inner      ringJSON          `json:"inner"`
+	Groups     []string          `json:"groups"`
+	Composites [][]string        `json:"composites,omitempty"`
+	Effects    map[string][3]int `json:"effects,omitempty"`
+}
+
+// MarshalJSON 转为 JSON 表示
+// 与字符串表示不同， JSON 表示包含 GroupEffect
+func (compass Compass) MarshalJSON() ([]byte, error) {
+	std := compass.Standardize()
+	ret := compassJSON{
+		Outer:  ringJSON{Location: std.OuterRing.Location, Speed: std.OuterRing.Speed},
+		Middle: ringJSON{Location: std.MiddleRing.Location, Speed: std.MiddleRing.Speed},
+		Inner:  ringJSON{Location: std.InnerRing.Location, Speed: std.InnerRing.Speed},
+		Groups: make([]string, len(std.RingGroups)),
+	}
+	for i, rg := range std.RingGroups {
+		ret.Groups[i] = rg.ShortName()
+	}
+	for _, composite := range std.CompositeGroups {
+		names := make([]string, len(composite))
+		for i, rg := range composite {
+			names[i] = rg.ShortName()
+		}
+		ret.Composites = append(ret.Composites, names)
+	}
+	if len(std.GroupEffect) > 0 {
+		ret.Effects = make(map[string][3]int, len(std.GroupEffect))
+		for rg, effect := range std.GroupEffect {
+			ret.Effects[rg.ShortName()] = effect
+		}
+	}
+	return json.Marshal(ret)
+}
+
+// UnmarshalJSON 解析 JSON 表示，格式参见 MarshalJSON
+// 格式错误时返回包装了 ErrParseFormat 的错误
+func (compass *Compass) UnmarshalJSON(data []byte) error {
+	var raw compassJSON
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return fmt.Errorf("%w: %s", ErrParseFormat, err)
+	}
+
+	ret := Compass{
+		OuterRing:  Ring{Location: raw.Outer.Location, Speed: raw.Outer.Speed},
+		MiddleRing: Ring{Location: raw.Middle.Location, Speed: raw.Middle.Speed},
+		InnerRing:  Ring{Location: raw.Inner.Location, Speed: raw.Inner.Speed},
+	}
+	for i, name := range raw.Groups {
+		rg, err := ParseRingGroup(name)
+		if err != nil {
+			return fmt.Errorf("parse the ring group at index %d error: %w", i, err)
+		}
+		ret.RingGroups = append(ret.RingGroups, rg)
+	}
+	for i, names := range raw.Composites {
+		composite, err := ParseCompositeGroup(strings.Join(names, "+"))
+		if err != nil {
+			return fmt.Errorf("parse the composite group at index %d error: %w", i, err)
+		}
+		ret.CompositeGroups = append(ret.CompositeGroups, composite)
+	}
+	// 按名称排序，使重复定义的错误信息稳定
+	names := make([]string, 0, len(raw.Effects))
+	for name := range raw.Effects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	effectNames := make(map[RingGroup]string, len(raw.Effects))
+	for _, name := range names {
+		rg, err := ParseRingGroup(name)
+		if err != nil {
+			return fmt.Errorf("parse the group effect of \"%s\" error: %w", name, err)
+		}
+		// 圈分组名不区分顺序和大小写，比如 "om" 和 "MO" 是同一个圈分组
+		if other, ok := effectNames[rg]; ok {
+			return fmt.Errorf("%w: duplicate group effects of ring group %s: \"%s\" and \"%s\"", ErrParseFormat, rg.Name(), other, name)
+		}
+		effectNames[rg] = name
+		if ret.GroupEffect == nil {
+			ret.GroupEffect = make(map[RingGroup][3]int, len(raw.Effects))
+		}
+		ret.GroupEffect[rg] = raw.Effects[name]
+	}
+	*compass = ret
+	return nil
+}
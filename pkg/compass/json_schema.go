@@ -0,0 +1,121 @@
+package compass
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// jsonSchemaDraft JSONSchema 生成的 JSON Schema 的版本
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaDescriptions compassJSON 各字段的说明，没有说明的字段视为遗漏
+var jsonSchemaDescriptions = map[string]string{
+	"outer":      "The outer ring.",
+	"middle":     "The middle ring.",
+	"inner":      "The inner ring.",
+	"groups":     "Ring groups supported by the compass, e.g. \"om\" rotates the outer and middle rings together.",
+	"composites": "Composite groups, each rotates all of its ring groups at once in a single click.",
+	"effects":    "Locations moved by the outer, middle and inner rings when the ring group is rotated once, overriding the speeds of the rings.",
+}
+
+// JSONSchema 返回罗盘 JSON 表示（参见 Compass.MarshalJSON ）的 JSON Schema
+// 由 compassJSON 的字段生成，字段增减时随之变化；字段的类型没有对应的 Schema 或缺少说明时返回错误
+func JSONSchema() ([]byte, error) {
+	properties := map[string]interface{}{}
+	var required []string
+	t := reflect.TypeOf(compassJSON{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		schema, err := jsonSchemaOf(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("json schema of field %s error: %w", field.Name, err)
+		}
+		description, ok := jsonSchemaDescriptions[name]
+		if !ok {
+			return nil, fmt.Errorf("missing description of field %s", field.Name)
+		}
+		schema["description"] = description
+		properties[name] = schema
+		if opts != "omitempty" {
+			required = append(required, name)
+		}
+	}
+	return json.MarshalIndent(map[string]interface{}{
+		"$schema":              jsonSchemaDraft,
+		"title":                "Navigation Compass",
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}, "", "  ")
+}
+
+// jsonSchemaOf 返回 compassJSON 中字段类型的 JSON Schema
+func jsonSchemaOf(t reflect.Type) (map[string]interface{}, error) {
+	switch t {
+	case reflect.TypeOf(ringJSON{}):
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"location": map[string]interface{}{
+					"description": "Clockwise angle from the target location (left) to the pointer, in units of 60 degrees.",
+					"type":        "integer",
+					"minimum":     0,
+					"maximum":     5,
+				},
+				"speed": map[string]interface{}{
+					"description": "Angle rotated by a single click in units of 60 degrees, positive for clockwise and negative for counterclockwise.",
+					"type":        "integer",
+				},
+			},
+			"required":             []string{"location", "speed"},
+			"additionalProperties": false,
+		}, nil
+	case reflect.TypeOf([]string{}):
+		return map[string]interface{}{
+			"type":  "array",
+			"items": ringGroupJSONSchema(),
+		}, nil
+	case reflect.TypeOf([][]string{}):
+		return map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type":     "array",
+				"items":    ringGroupJSONSchema(),
+				"minItems": 2,
+			},
+		}, nil
+	case reflect.TypeOf(map[string][3]int{}):
+		return map[string]interface{}{
+			"type":          "object",
+			"propertyNames": ringGroupJSONSchema(),
+			"additionalProperties": map[string]interface{}{
+				"type":     "array",
+				"items":    map[string]interface{}{"type": "integer"},
+				"minItems": 3,
+				"maxItems": 3,
+			},
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported type: %s", t)
+}
+
+// ringGroupJSONSchema 返回圈分组名的 JSON Schema ，即各圈分组的简写名及其中各圈交换顺序的写法
+// 解析时不区分大小写，这里只列出小写的写法
+func ringGroupJSONSchema() map[string]interface{} {
+	var names []string
+	for _, rg := range allRingGroups {
+		name := rg.ShortName()
+		names = append(names, name)
+		if len(name) == 2 {
+			names = append(names, name[1:]+name[:1])
+		}
+	}
+	return map[string]interface{}{
+		"type": "string",
+		"enum": names,
+	}
+}
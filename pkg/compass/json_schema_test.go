@@ -0,0 +1,68 @@
+package compass
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// TestJSONSchema 测试 JSONSchema ，各属性与 JSON 表示的字段一致
+func TestJSONSchema(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var schema struct {
+		Properties map[string]struct {
+			Items struct {
+				Enum []string `json:"enum"`
+			} `json:"items"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("unmarshal schema error: %s", err)
+	}
+
+	// 属性即罗盘 JSON 表示的所有字段
+	var raw map[string]json.RawMessage
+	c := Compass{
+		RingGroups:      []RingGroup{OuterMiddleRingGroup, InnerRingGroup},
+		CompositeGroups: [][]RingGroup{{OuterMiddleRingGroup, InnerRingGroup}},
+		GroupEffect:     map[RingGroup][3]int{InnerRingGroup: {0, 0, 2}},
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("marshal compass error: %s", err)
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		t.Fatalf("unmarshal compass error: %s", err)
+	}
+	var fields, properties []string
+	for name := range raw {
+		fields = append(fields, name)
+	}
+	for name := range schema.Properties {
+		properties = append(properties, name)
+	}
+	sort.Strings(fields)
+	sort.Strings(properties)
+	if !reflect.DeepEqual(properties, fields) {
+		t.Errorf("unexpected properties: %v (expected: %v)", properties, fields)
+	}
+	if expected := []string{"outer", "middle", "inner", "groups"}; !reflect.DeepEqual(schema.Required, expected) {
+		t.Errorf("unexpected required properties: %v (expected: %v)", schema.Required, expected)
+	}
+
+	// 圈分组名都可以解析
+	groups := schema.Properties["groups"].Items.Enum
+	if len(groups) != 9 {
+		t.Errorf("unexpected ring group names: %v (expected 9 names)", groups)
+	}
+	for _, name := range groups {
+		if _, err := ParseRingGroup(name); err != nil {
+			t.Errorf("unexpected error parsing ring group name %#v: %s", name, err)
+		}
+	}
+}
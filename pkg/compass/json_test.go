@@ -0,0 +1,74 @@
+package compass
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestCompassMarshalJSON 测试 Compass.MarshalJSON 和 Compass.UnmarshalJSON 方法
+func TestCompassMarshalJSON(t *testing.T) {
+	c := Compass{
+		OuterRing:       Ring{Location: 0, Speed: 1},
+		MiddleRing:      Ring{Location: 4, Speed: -4},
+		InnerRing:       Ring{Location: 0, Speed: 2},
+		RingGroups:      []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup, OuterInnerRingGroup},
+		CompositeGroups: [][]RingGroup{{OuterMiddleRingGroup, MiddleInnerRingGroup}},
+		GroupEffect:     map[RingGroup][3]int{OuterMiddleRingGroup: {1, 1, 0}},
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedRet := `{"outer":{"location":0,"speed":1},"middle":{"location":4,"speed":-4},"inner":{"location":0,"speed":2},` +
+		`"groups":["mi","oi","om"],"composites":[["mi","om"]],"effects":{"om":[1,1,0]}}`
+	if string(data) != expectedRet {
+		t.Errorf("unexpected result: %s (expected: %s)", data, expectedRet)
+	}
+
+	var ret Compass
+	if err := json.Unmarshal(data, &ret); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ret.Equal(&c) {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, c)
+	}
+
+	// 未知字段、非法圈分组及重复的圈分组位移
+	for _, data := range []string{
+		`{"outer":{"location":0,"speed":1},"groups":["o"],"foo":1}`,
+		`{"outer":{"location":0,"speed":1},"groups":["x"]}`,
+		`{"outer":{"location":0,"speed":1},"groups":["om"],"effects":{"om":[1,1,0],"MO":[1,2,0]}}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &ret); !errors.Is(err, ErrParseFormat) {
+			t.Errorf("unexpected error of %s: %v (expected: %s)", data, err, ErrParseFormat)
+		}
+	}
+}
+
+// TestParseCompassJSON 测试 ParseCompass 同时支持字符串表示和 JSON 表示
+func TestParseCompassJSON(t *testing.T) {
+	expectedRet := "0+1,4-4,0+2/mi,oi,om"
+	for _, input := range []string{
+		"0+1,4-4,0+2/oi,om,mi",
+		` {"outer":{"location":0,"speed":1},"middle":{"location":4,"speed":-4},"inner":{"location":0,"speed":2},"groups":["oi","om","mi"]}`,
+	} {
+		ret, err := ParseCompass(input)
+		if err != nil {
+			t.Errorf("unexpected error of %s: %s", input, err)
+			continue
+		}
+		if ret.String() != expectedRet {
+			t.Errorf("unexpected result of %s: %s (expected: %s)", input, ret.String(), expectedRet)
+		}
+	}
+
+	// 都无法解析时返回两者的错误
+	_, err := ParseCompass(`{"outer":`)
+	if !errors.Is(err, ErrParseFormat) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrParseFormat)
+	} else if !strings.Contains(err.Error(), "parse as JSON error") || !strings.Contains(err.Error(), "parse as expression error") {
+		t.Errorf("unexpected error: %s (expected to contain both errors)", err)
+	}
+}
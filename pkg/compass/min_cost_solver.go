@@ -0,0 +1,191 @@
+package compass
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+// NewMinCostSolver 创建一个最小代价引航罗盘求解器
+// 求解器返回总代价（各步骤转动次数乘以对应圈分组代价之和）最小的解法，
+// 圈分组的代价由 SolverOptions.GroupCost 指定，所有代价均为 1 时等价于默认求解器
+func NewMinCostSolver(opts SolverOptions) (Solver, error) {
+	if len(opts.GroupLimits) > 0 {
+		return nil, fmt.Errorf("group limits are not supported by min cost solver")
+	}
+	for rg, cost := range opts.GroupCost {
+		if cost <= 0 {
+			return nil, fmt.Errorf("invalid cost of ring group %s: %d (must be positive)", rg.Name(), cost)
+		}
+	}
+	return &minCostSolver{
+		logger:    opts.Logger,
+		groupCost: opts.GroupCost,
+		avoid:     opts.Avoid,
+		noRepeat:  opts.NoConsecutiveRepeat,
+	}, nil
+}
+
+// minCostSolver 最小代价引航罗盘求解器
+// 在罗盘状态图上使用 Dijkstra 算法搜索
+// 禁止连续两次转动相同的圈分组时，搜索的节点是罗盘状态及到达该状态的最后一次转动
+type minCostSolver struct {
+	logger    logr.Logger
+	groupCost map[RingGroup]int
+	avoid     func(*Compass) bool
+	noRepeat  bool
+	// stepCost 按步骤转动一次的代价，为 nil 时使用 groupCost
+	stepCost func(compass *Compass, step *Step) int
+}
+
+var _ Solver = &minCostSolver{}
+
+// Solve 求解引航罗盘
+func (s *minCostSolver) Solve(ctx context.Context, compass Compass) (Steps, error) {
+	// 校验入参
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if err := compass.PreSolveCheck(); err != nil {
+		return nil, err
+	}
+	moves := compass.moves()
+
+	// 搜索的节点为 hash*width+last ，其中 last 为到达该状态的最后一次转动在 moves 中的下标，
+	// 初始状态的 last 为 len(moves) ；不禁止连续转动相同的圈分组时不区分最后一次转动， width 为 1
+	width := 1
+	if s.noRepeat {
+		width = len(moves) + 1
+	}
+	nodeOf := func(hash, last int) int {
+		if !s.noRepeat {
+			return hash
+		}
+		return hash*width + last
+	}
+
+	// 各节点的最小代价及到达该节点的上一步
+	var (
+		costs    = make([]int, 216*width)
+		visited  = make([]bool, 216*width)
+		prev     = make([]int, 216*width)
+		prevMove = make([]Step, 216*width)
+	)
+	for i := range costs {
+		costs[i] = -1
+	}
+	start := nodeOf(compass.Hash(), len(moves))
+	costs[start] = 0
+	queue := &costQueue{{node: start, cost: 0}}
+
+	end := -1
+	for queue.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		cur := heap.Pop(queue).(costItem)
+		if visited[cur.node] {
+			continue
+		}
+		visited[cur.node] = true
+		if cur.node/width == targetHash {
+			end = cur.node
+			break
+		}
+		for i, move := range moves {
+			if s.noRepeat && cur.node%width == i {
+				continue
+			}
+			next := nodeOf(rotateHashStep(&compass, cur.node/width, &move), i)
+			cost := cur.cost + s.cost(&compass, &move)
+			if visited[next] || (costs[next] >= 0 && costs[next] <= cost) {
+				continue
+			}
+			if s.avoid != nil && s.avoid(compassAtHash(&compass, next/width)) {
+				continue
+			}
+			costs[next] = cost
+			prev[next] = cur.node
+			prevMove[next] = move
+			heap.Push(queue, costItem{node: next, cost: cost})
+		}
+	}
+	if end < 0 {
+		if err := compass.Solvability(); err != nil {
+			return nil, err
+		}
+		if s.avoid != nil {
+			return nil, fmt.Errorf("%w: no solution avoiding the forbidden states", ErrUnsolvable)
+		}
+		if s.noRepeat {
+			return nil, fmt.Errorf("%w: no solution without rotating the same group twice in a row", ErrUnsolvable)
+		}
+		return nil, ErrUnsolvable
+	}
+
+	// 回溯得到解法
+	var solution Steps
+	for cur := end; cur != start; cur = prev[cur] {
+		solution = append(solution, prevMove[cur])
+	}
+	s.logger.V(1).Info(fmt.Sprintf("found solution '%s' with cost %d", solution.String(), costs[end]))
+	if s.avoid != nil || s.noRepeat {
+		// 需要避开部分状态或禁止连续转动相同的圈分组时转动顺序会影响结果，保持转动顺序
+		for i, j := 0, len(solution)-1; i < j; i, j = i+1, j-1 {
+			solution[i], solution[j] = solution[j], solution[i]
+		}
+		return solution.compact(), nil
+	}
+	return solution.Standardize(), nil
+}
+
+// cost 返回按步骤转动一次的代价
+func (s *minCostSolver) cost(compass *Compass, step *Step) int {
+	if s.stepCost != nil {
+		return s.stepCost(compass, step)
+	}
+	return stepCost(s.groupCost, step)
+}
+
+// stepCost 返回按步骤转动一次的代价，未指定代价的圈分组代价为 1
+// 复合圈分组的代价为其中各圈分组代价的最大值
+func stepCost(groupCost map[RingGroup]int, step *Step) int {
+	costOf := func(rg RingGroup) int {
+		if cost, ok := groupCost[rg]; ok {
+			return cost
+		}
+		return 1
+	}
+	if !step.IsComposite() {
+		return costOf(step.RingGroup)
+	}
+	max := 0
+	for _, rg := range step.Composite {
+		if cost := costOf(rg); cost > max {
+			max = cost
+		}
+	}
+	return max
+}
+
+// costItem 优先队列中的元素
+type costItem struct {
+	node int
+	cost int
+}
+
+// costQueue 按代价排序的优先队列，实现 heap.Interface
+type costQueue []costItem
+
+func (q costQueue) Len() int            { return len(q) }
+func (q costQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q costQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *costQueue) Push(x interface{}) { *q = append(*q, x.(costItem)) }
+func (q *costQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
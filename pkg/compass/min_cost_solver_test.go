@@ -0,0 +1,129 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestMinCostSolver 测试最小代价求解器
+func TestMinCostSolver(t *testing.T) {
+	c := Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 1, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+	}
+	cases := []struct {
+		groupCost   map[RingGroup]int
+		expectedRet string
+	}{
+		// 默认代价等价于最少转动次数
+		{groupCost: nil, expectedRet: "om5"},
+		{groupCost: map[RingGroup]int{OuterMiddleRingGroup: 3}, expectedRet: "m5,o5"},
+	}
+
+	for _, tc := range cases {
+		solver, err := NewMinCostSolver(SolverOptions{Logger: logr.Discard(), GroupCost: tc.groupCost})
+		if err != nil {
+			t.Errorf("new min cost solver error: %s", err)
+			return
+		}
+		ret, err := solver.Solve(context.Background(), c)
+		if err != nil {
+			t.Errorf("compass solve error: %s", err)
+			return
+		}
+		if ret.String() != tc.expectedRet {
+			t.Errorf("unexpected result with cost %v: %#v (expected: %#v)", tc.groupCost, ret.String(), tc.expectedRet)
+		}
+	}
+}
+
+// TestMinCostSolverAvoid 测试最小代价求解器避开指定状态
+func TestMinCostSolverAvoid(t *testing.T) {
+	c := Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 1, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+	}
+	// 不避开时的解法 om5 会经过 3,3,0
+	trap := func(c *Compass) bool {
+		return c.OuterRing.Location == 3 && c.MiddleRing.Location == 3 && c.InnerRing.Location == 0
+	}
+	solver, err := NewMinCostSolver(SolverOptions{Logger: logr.Discard(), Avoid: trap})
+	if err != nil {
+		t.Fatalf("new min cost solver error: %s", err)
+	}
+	ret, err := solver.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatalf("compass solve error: %s", err)
+	}
+	if ret.TotalCount() <= 5 {
+		t.Errorf("unexpected moves: %d (expected: more than %d)", ret.TotalCount(), 5)
+	}
+	// 逐次转动，不经过需要避开的状态且最终解开罗盘
+	cur := c.Clone()
+	for _, step := range ret {
+		for i := 0; i < step.Count; i++ {
+			if err := cur.ApplySteps(Steps{{RingGroup: step.RingGroup, Composite: step.Composite, Count: 1}}); err != nil {
+				t.Fatalf("apply steps error: %s", err)
+			}
+			if trap(cur) {
+				t.Errorf("unexpected state in solution %s: %s", ret.OrderedString(), cur.String())
+			}
+		}
+	}
+	if cur.Hash() != 0 {
+		t.Errorf("unexpected final state of solution %s: %s (expected solved)", ret.OrderedString(), cur.String())
+	}
+
+	// 目标状态也需要避开时无解
+	solver, err = NewMinCostSolver(SolverOptions{Logger: logr.Discard(), Avoid: func(c *Compass) bool { return c.Hash() == 0 }})
+	if err != nil {
+		t.Fatalf("new min cost solver error: %s", err)
+	}
+	if _, err := solver.Solve(context.Background(), c); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrUnsolvable)
+	}
+}
+
+// TestMinCostSolverNoConsecutiveRepeat 测试最小代价求解器禁止连续两次转动相同的圈分组
+func TestMinCostSolverNoConsecutiveRepeat(t *testing.T) {
+	c := Compass{
+		OuterRing:  Ring{Location: 4, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+	}
+	// 不禁止时的解法为 o2
+	solver, err := NewMinCostSolver(SolverOptions{Logger: logr.Discard(), NoConsecutiveRepeat: true})
+	if err != nil {
+		t.Fatalf("new min cost solver error: %s", err)
+	}
+	ret, err := solver.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatalf("compass solve error: %s", err)
+	}
+	if ret.TotalCount() <= 2 {
+		t.Errorf("unexpected moves of %s: %d (expected: more than %d)", ret.OrderedString(), ret.TotalCount(), 2)
+	}
+	for i, step := range ret {
+		if step.Count != 1 || (i > 0 && step.RingGroup == ret[i-1].RingGroup) {
+			t.Errorf("unexpected solution: %s (expected no group rotated twice in a row)", ret.OrderedString())
+			break
+		}
+	}
+	if ok, err := CheckSolution(c, ret); err != nil || !ok {
+		t.Errorf("unexpected check result of %s: %t, %v (expected: true)", ret.OrderedString(), ok, err)
+	}
+
+	// 只有外圈及中圈分组时，外圈转动次数模 6 余 2 ，中圈转动次数是 6 的倍数，无法交替转动
+	c.RingGroups = []RingGroup{OuterRingGroup, MiddleRingGroup}
+	if _, err := solver.Solve(context.Background(), c); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrUnsolvable)
+	}
+}
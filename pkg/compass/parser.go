@@ -1,6 +1,7 @@
 package compass
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -8,57 +9,121 @@ import (
 )
 
 const (
-	compassRegexpStr = `(?P<outerRing>[0-9-+]+),` +
-		`(?P<middleRing>[0-9-+]+),` +
-		`(?P<innerRing>[0-9-+]+)/` +
-		`(?P<ringGroups>[imo,]+)`
-	ringRegexpStr = `(?P<location>[0-5])(?P<speed>(?:\+|-)[1-4])`
+	compassRegexpStr = `^\s*(?P<outerRing>[0-9a-zA-Z-+\s]+),` +
+		`(?P<middleRing>[0-9a-zA-Z-+\s]+),` +
+		`(?P<innerRing>[0-9a-zA-Z-+\s]+)/` +
+		`(?P<ringGroups>(?i:[imo,()+\s]+))$`
+	stepRegexpStr = `^\s*(?:\((?P<composite>(?i:[imo+\s]+))\)|(?P<ringGroup>(?i:[imo]+)))\s*(?P<count>[0-9]+)\s*$`
+	ringRegexpStr = `^\s*(?P<location>[0-5])\s*(?P<speed>(?:\+|-)[0-9]{1,2})\s*$`
+	// 以刻度数及方向表示旋转速度的罗盘圈
+	notchesRingRegexpStr = `^\s*(?P<location>[0-5])\s*(?P<direction>(?i:cw|ccw))\s*(?P<notches>[1-4])\s*$`
 )
 
 var (
 	compassRegexp = regexp.MustCompile(compassRegexpStr)
+	stepRegexp    = regexp.MustCompile(stepRegexpStr)
 	ringRegexp    = regexp.MustCompile(ringRegexpStr)
+
+	notchesRingRegexp = regexp.MustCompile(notchesRingRegexpStr)
 )
 
 // ParseCompass 解析字符串表示的罗盘信息
+// 容忍各部分前后的空白字符及大写的圈组，比如 "3 +1, 0-2 ,5+0 / O, MI" 。
+// 圈组中以括号包围、以 + 分隔的是复合圈组，比如 "3+1,0-2,5+2/o,mi,(o+mi)" 。
+// 以 { 开头时先尝试按 JSON 表示解析（参见 Compass.MarshalJSON ），失败时再按字符串表示解析，都失败时返回两者的错误
 func ParseCompass(compass string) (Compass, error) {
+	if !strings.HasPrefix(strings.TrimSpace(compass), "{") {
+		return parseCompass(compass, ParseRing)
+	}
+	var ret Compass
+	jsonErr := json.Unmarshal([]byte(compass), &ret)
+	if jsonErr == nil {
+		return ret, nil
+	}
+	ret, err := parseCompass(compass, ParseRing)
+	if err != nil {
+		return ret, fmt.Errorf("%w: parse as JSON error: %s; parse as expression error: %s", ErrParseFormat, jsonErr, err)
+	}
+	return ret, nil
+}
+
+// ParseCompassNotches 解析字符串表示的罗盘信息，其中各圈的旋转速度以刻度数及方向表示
+// 比如 "0cw1,4ccw4,0cw2/oi,om,mi" ，与 "0+1,4-4,0+2/oi,om,mi" 等价，参见 ParseRingNotches
+func ParseCompassNotches(compass string) (Compass, error) {
+	return parseCompass(compass, ParseRingNotches)
+}
+
+// parseCompass 解析字符串表示的罗盘信息，使用 parseRing 解析各圈
+func parseCompass(compass string, parseRing func(string) (Ring, error)) (Compass, error) {
 	ret := Compass{}
 
 	// 正则
 	groups := compassRegexp.FindStringSubmatch(compass)
 	if groups == nil {
-		return ret, fmt.Errorf("invalid compass expression: \"%s\" (not match \"%s\")", compass, compassRegexpStr)
+		return ret, fmt.Errorf("%w: compass expression \"%s\" not match \"%s\"", ErrParseFormat, compass, compassRegexpStr)
 	}
 
 	// 各捕获组分别解析
 
-	outer, err := ParseRing(groups[compassRegexp.SubexpIndex("outerRing")])
+	outer, err := parseRing(groups[compassRegexp.SubexpIndex("outerRing")])
 	if err != nil {
 		return ret, fmt.Errorf("parse outer ring error: %w", err)
 	}
 	ret.OuterRing = outer
 
-	middle, err := ParseRing(groups[compassRegexp.SubexpIndex("middleRing")])
+	middle, err := parseRing(groups[compassRegexp.SubexpIndex("middleRing")])
 	if err != nil {
 		return ret, fmt.Errorf("parse middle ring error: %w", err)
 	}
 	ret.MiddleRing = middle
 
-	inner, err := ParseRing(groups[compassRegexp.SubexpIndex("innerRing")])
+	inner, err := parseRing(groups[compassRegexp.SubexpIndex("innerRing")])
 	if err != nil {
 		return ret, fmt.Errorf("parse inner ring error: %w", err)
 	}
 	ret.InnerRing = inner
 
-	rgs, err := ParseRingGroups(groups[compassRegexp.SubexpIndex("ringGroups")])
-	if err != nil {
-		return ret, fmt.Errorf("parse ring groups error: %w", err)
+	// 圈组中以括号包围的是复合圈组
+	for i, rgStr := range strings.Split(groups[compassRegexp.SubexpIndex("ringGroups")], ",") {
+		if strings.HasPrefix(strings.TrimSpace(rgStr), "(") {
+			composite, err := ParseCompositeGroup(rgStr)
+			if err != nil {
+				return ret, fmt.Errorf("parse ring groups error: parse the composite group at index %d error: %w", i, err)
+			}
+			ret.CompositeGroups = append(ret.CompositeGroups, composite)
+			continue
+		}
+		rg, err := ParseRingGroup(rgStr)
+		if err != nil {
+			return ret, fmt.Errorf("parse ring groups error: parse the ring group at index %d error: %w", i, err)
+		}
+		ret.RingGroups = append(ret.RingGroups, rg)
 	}
-	ret.RingGroups = rgs
 
 	return ret, nil
 }
 
+// ParseCompositeGroup 解析字符串表示的复合圈组，比如 "(o+mi)"
+// 括号可以省略，各圈组以 + 分隔
+func ParseCompositeGroup(composite string) ([]RingGroup, error) {
+	str := strings.TrimSpace(composite)
+	if strings.HasPrefix(str, "(") {
+		if !strings.HasSuffix(str, ")") {
+			return nil, fmt.Errorf("%w: unclosed parenthesis in composite group expression \"%s\"", ErrParseFormat, composite)
+		}
+		str = str[1 : len(str)-1]
+	}
+	var ret []RingGroup
+	for i, rgStr := range strings.Split(str, "+") {
+		rg, err := ParseRingGroup(rgStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse the ring group at index %d error: %w", i, err)
+		}
+		ret = append(ret, rg)
+	}
+	return ret, nil
+}
+
 // ParseRingGroups 解析字符串表示的罗盘圈组列表
 func ParseRingGroups(ringGroups string) ([]RingGroup, error) {
 	var ret []RingGroup
@@ -74,8 +139,9 @@ func ParseRingGroups(ringGroups string) ([]RingGroup, error) {
 }
 
 // ParseRingGroup 解析字符串表示的罗盘圈组
+// 忽略首尾空白字符及大小写
 func ParseRingGroup(ringGroup string) (RingGroup, error) {
-	switch ringGroup {
+	switch strings.ToLower(strings.TrimSpace(ringGroup)) {
 	case "o":
 		return OuterRingGroup, nil
 	case "m":
@@ -89,17 +155,18 @@ func ParseRingGroup(ringGroup string) (RingGroup, error) {
 	case "mi", "im":
 		return MiddleInnerRingGroup, nil
 	}
-	return 0, fmt.Errorf("unknown ring group: %s", ringGroup)
+	return 0, fmt.Errorf("%w: unknown ring group: %s", ErrParseFormat, ringGroup)
 }
 
-// ParseRing 解析字符串表示的罗盘圈
+// ParseRing 解析字符串表示的罗盘圈，比如 "5+2" ，不转动的圈的旋转速度为 0 ，比如 "5+0"
+// 旋转速度可以超过一周（比如 "5+7" ，至多两位数），原样保留，参见 Compass.RawSpeedString
 func ParseRing(ring string) (Ring, error) {
 	ret := Ring{}
 
 	// 正则
 	groups := ringRegexp.FindStringSubmatch(ring)
 	if groups == nil {
-		return ret, fmt.Errorf("invalid ring expression: \"%s\" (not match \"%s\")", ring, ringRegexpStr)
+		return ret, fmt.Errorf("%w: ring expression \"%s\" not match \"%s\"", ErrParseFormat, ring, ringRegexpStr)
 	}
 
 	locationStr := groups[ringRegexp.SubexpIndex("location")]
@@ -118,3 +185,85 @@ func ParseRing(ring string) (Ring, error) {
 
 	return ret, nil
 }
+
+// ParseSteps 解析字符串表示的转动步骤组合，即 Steps.String 的输出
+// 空字符串表示无需转动
+func ParseSteps(steps string) (Steps, error) {
+	if strings.TrimSpace(steps) == "" {
+		return nil, nil
+	}
+	var ret Steps
+	// 按 , 切分各步骤解析
+	for i, stepStr := range strings.Split(steps, ",") {
+		step, err := ParseStep(stepStr)
+		if err != nil {
+			return nil, fmt.Errorf("parse the step at index %d error: %w", i, err)
+		}
+		ret = append(ret, step)
+	}
+	return ret, nil
+}
+
+// ParseStep 解析字符串表示的转动步骤，比如 "om2" ，转动复合圈组时比如 "(o+mi)2"
+func ParseStep(step string) (Step, error) {
+	ret := Step{}
+
+	// 正则
+	groups := stepRegexp.FindStringSubmatch(step)
+	if groups == nil {
+		return ret, fmt.Errorf("%w: step expression \"%s\" not match \"%s\"", ErrParseFormat, step, stepRegexpStr)
+	}
+
+	if compositeStr := groups[stepRegexp.SubexpIndex("composite")]; compositeStr != "" {
+		composite, err := ParseCompositeGroup(compositeStr)
+		if err != nil {
+			return ret, fmt.Errorf("parse step composite group error: %w", err)
+		}
+		ret.Composite = composite
+	} else {
+		rg, err := ParseRingGroup(groups[stepRegexp.SubexpIndex("ringGroup")])
+		if err != nil {
+			return ret, fmt.Errorf("parse step ring group error: %w", err)
+		}
+		ret.RingGroup = rg
+	}
+
+	countStr := groups[stepRegexp.SubexpIndex("count")]
+	count, err := strconv.ParseInt(countStr, 10, 32)
+	if err != nil {
+		return ret, fmt.Errorf("parse step count \"%s\" error: %w", countStr, err)
+	}
+	ret.Count = int(count)
+
+	return ret, nil
+}
+
+// ParseRingNotches 解析以刻度数及方向表示旋转速度的罗盘圈，比如 "4ccw2"
+// 格式为 {位置}{方向}{刻度数}，方向为 cw （顺时针）或 ccw （逆时针），
+// 刻度数为每次旋转经过的刻度数（即 60 度的倍数），参见 SpeedFromNotches
+func ParseRingNotches(ring string) (Ring, error) {
+	ret := Ring{}
+
+	// 正则
+	groups := notchesRingRegexp.FindStringSubmatch(ring)
+	if groups == nil {
+		return ret, fmt.Errorf("%w: ring expression \"%s\" not match \"%s\"", ErrParseFormat, ring, notchesRingRegexpStr)
+	}
+
+	locationStr := groups[notchesRingRegexp.SubexpIndex("location")]
+	location, err := strconv.ParseInt(locationStr, 10, 8)
+	if err != nil {
+		return ret, fmt.Errorf("parse ring location \"%s\" error: %w", locationStr, err)
+	}
+	ret.Location = int(location)
+
+	notchesStr := groups[notchesRingRegexp.SubexpIndex("notches")]
+	notches, err := strconv.ParseInt(notchesStr, 10, 8)
+	if err != nil {
+		return ret, fmt.Errorf("parse ring notches \"%s\" error: %w", notchesStr, err)
+	}
+	clockwise := strings.EqualFold(groups[notchesRingRegexp.SubexpIndex("direction")], "cw")
+	ret.Speed = SpeedFromNotches(int(notches), clockwise)
+
+	return ret, nil
+}
@@ -1,6 +1,7 @@
 package compass
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -23,7 +24,116 @@ func TestParseCompass(t *testing.T) {
 		},
 	}
 
-	if compass.String() != expectedRet.String() {
+	if !compass.Equal(&expectedRet) {
 		t.Errorf("unexpected result: %#v (expected: %#v)", compass.String(), expectedRet.String())
 	}
 }
+
+// TestParseCompassTolerant 测试 ParseCompass 对空白字符及大小写的容忍
+func TestParseCompassTolerant(t *testing.T) {
+	expectedRet := Compass{
+		OuterRing:  Ring{Location: 3, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: -2},
+		InnerRing:  Ring{Location: 5, Speed: 2},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleInnerRingGroup},
+	}
+	for _, input := range []string{
+		"3+1,0-2,5+2/o,mi",
+		"3 +1, 0-2 ,5+2 / o, mi",
+		" 3+1,0-2,5+2/O,MI\n",
+		"3+1,0-2,5+2/o,Im",
+	} {
+		compass, err := ParseCompass(input)
+		if err != nil {
+			t.Errorf("unexpected error parsing %#v: %s", input, err)
+			continue
+		}
+		if !compass.Equal(&expectedRet) {
+			t.Errorf("unexpected result parsing %#v: %#v (expected: %#v)", input, compass.String(), expectedRet.String())
+		}
+	}
+
+	// 格式错误的输入仍然应该报错
+	for _, input := range []string{
+		"3+1,0-2,5+2/o,mi,",
+		"3+1,0-2,5+2/o,,mi",
+		"3+1,0-2/o,mi",
+		"31,0-2,5+2/o,mi",
+		"3+1,0-2,5+2/o,mi/o",
+		"x3+1,0-2,5+2/o,mi",
+		"3+1,0-2,5+2/o m",
+	} {
+		if _, err := ParseCompass(input); err == nil {
+			t.Errorf("expected error parsing %#v", input)
+		}
+	}
+}
+
+// TestParseRing 测试 ParseRing
+func TestParseRing(t *testing.T) {
+	for input, expectedRet := range map[string]Ring{
+		"5+2":  {Location: 5, Speed: 2},
+		"3-4":  {Location: 3, Speed: -4},
+		"5+0":  {Location: 5, Speed: 0},
+		" 0-0": {Location: 0, Speed: 0},
+		"5+7":  {Location: 5, Speed: 7},
+		"1-12": {Location: 1, Speed: -12},
+	} {
+		ring, err := ParseRing(input)
+		if err != nil {
+			t.Errorf("unexpected error parsing %#v: %s", input, err)
+			continue
+		}
+		if ring != expectedRet {
+			t.Errorf("unexpected result parsing %#v: %#v (expected: %#v)", input, ring, expectedRet)
+		}
+	}
+
+	for _, input := range []string{"5", "5 0", "6+1", "+1", "5+100", "5+1000"} {
+		if _, err := ParseRing(input); !errors.Is(err, ErrParseFormat) {
+			t.Errorf("unexpected error parsing %#v: %v (expected: %s)", input, err, ErrParseFormat)
+		}
+	}
+}
+
+// TestParseSteps 测试 ParseSteps
+func TestParseSteps(t *testing.T) {
+	steps, err := ParseSteps("mi2, OI4,om2,(mi+o)1")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	expectedRet := "mi2,oi4,om2,(mi+o)1"
+	if steps.String() != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", steps.String(), expectedRet)
+	}
+
+	for _, input := range []string{"mi", "2", "mi2,", "x2", "(mi+o2", "(mi+)2"} {
+		if _, err := ParseSteps(input); err == nil {
+			t.Errorf("expected error parsing %#v", input)
+		}
+	}
+}
+
+// TestParseCompassNotches 测试 ParseCompassNotches
+func TestParseCompassNotches(t *testing.T) {
+	compass, err := ParseCompassNotches("0cw1, 4CCW4,0 cw 2/oi,om,mi")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	expectedRet, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+		return
+	}
+	if !compass.Equal(&expectedRet) {
+		t.Errorf("unexpected result: %#v (expected: %#v)", compass.String(), expectedRet.String())
+	}
+
+	for _, input := range []string{"0+1,4-4,0+2/oi,om,mi", "0cw,4ccw4,0cw2/oi", "0cw5,4ccw4,0cw2/oi"} {
+		if _, err := ParseCompassNotches(input); err == nil {
+			t.Errorf("expected error parsing %#v", input)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package compass
+
+// RandomSolveProbability 返回随机转动 steps 次后罗盘恰好解开的概率
+// 即长度为 steps 、每一步从当前罗盘支持的圈分组及复合圈分组中任选一个转动一次的所有序列中，最终解开罗盘的序列所占的比例。
+// 从初始状态出发逐步按状态转移（每种转动的概率相同）计算各状态的概率分布，不需要枚举所有序列。
+// 罗盘为 nil 或 steps 为负数时返回 0 ；没有可以转动的圈分组时，只有 steps 为 0 且罗盘已解开时返回 1
+func (compass *Compass) RandomSolveProbability(steps int) float64 {
+	if compass == nil || steps < 0 {
+		return 0
+	}
+	moves := compass.moves()
+	if len(moves) == 0 && steps > 0 {
+		return 0
+	}
+	var dist [216]float64
+	dist[compass.Hash()] = 1
+	for i := 0; i < steps; i++ {
+		var next [216]float64
+		for hash, p := range dist {
+			if p == 0 {
+				continue
+			}
+			p /= float64(len(moves))
+			for j := range moves {
+				next[rotateHashStep(compass, hash, &moves[j])] += p
+			}
+		}
+		dist = next
+	}
+	return dist[targetHash]
+}
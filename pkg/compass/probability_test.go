@@ -0,0 +1,49 @@
+package compass
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCompassRandomSolveProbability 测试 Compass.RandomSolveProbability ，与枚举所有转动序列的结果比较
+func TestCompassRandomSolveProbability(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	c.CompositeGroups = [][]RingGroup{{OuterInnerRingGroup, OuterMiddleRingGroup}}
+	moves := c.moves()
+
+	// 枚举所有长度为 steps 的序列，统计解开罗盘的序列数
+	var count func(hash, steps int) int
+	count = func(hash, steps int) int {
+		if steps == 0 {
+			if hash == targetHash {
+				return 1
+			}
+			return 0
+		}
+		ret := 0
+		for i := range moves {
+			ret += count(rotateHashStep(&c, hash, &moves[i]), steps-1)
+		}
+		return ret
+	}
+	for steps := 0; steps <= 6; steps++ {
+		expected := float64(count(c.Hash(), steps)) / math.Pow(float64(len(moves)), float64(steps))
+		if ret := c.RandomSolveProbability(steps); math.Abs(ret-expected) > 1e-12 {
+			t.Errorf("unexpected result of %d steps: %g (expected: %g)", steps, ret, expected)
+		}
+	}
+
+	if ret := c.RandomSolveProbability(-1); ret != 0 {
+		t.Errorf("unexpected result of negative steps: %g (expected: 0)", ret)
+	}
+	solved := &Compass{}
+	if ret := solved.RandomSolveProbability(0); ret != 1 {
+		t.Errorf("unexpected result of solved compass without groups: %g (expected: 1)", ret)
+	}
+	if ret := solved.RandomSolveProbability(1); ret != 0 {
+		t.Errorf("unexpected result of solved compass without groups: %g (expected: 0)", ret)
+	}
+}
@@ -0,0 +1,87 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// puzzleSeparator 字符串表示中各罗盘间的分隔符
+	puzzleSeparator = ";"
+)
+
+// Puzzle 由多个互相独立、需要全部解开的引航罗盘组成的谜题
+type Puzzle struct {
+	Compasses []*Compass
+}
+
+// ParsePuzzle 解析字符串表示的谜题，各罗盘以 ; 分隔，每个罗盘的格式同 ParseCompass
+// 比如 "3+1,0-2,5+0/o,mi;0+1,4-4,0+2/oi,om,mi" 。不包含 ; 时即只有一个罗盘的谜题
+func ParsePuzzle(puzzle string) (Puzzle, error) {
+	return ParsePuzzleFunc(puzzle, ParseCompass)
+}
+
+// ParsePuzzleNotches 同 ParsePuzzle ，但各罗盘的格式同 ParseCompassNotches
+func ParsePuzzleNotches(puzzle string) (Puzzle, error) {
+	return ParsePuzzleFunc(puzzle, ParseCompassNotches)
+}
+
+// ParsePuzzleFunc 解析字符串表示的谜题，各罗盘以 ; 分隔，使用 parseCompass 解析各罗盘
+func ParsePuzzleFunc(puzzle string, parseCompass func(string) (Compass, error)) (Puzzle, error) {
+	parts := strings.Split(puzzle, puzzleSeparator)
+	ret := Puzzle{Compasses: make([]*Compass, len(parts))}
+	for i, part := range parts {
+		c, err := parseCompass(part)
+		if err != nil {
+			return Puzzle{}, fmt.Errorf("parse compass %d error: %w", i+1, err)
+		}
+		ret.Compasses[i] = &c
+	}
+	return ret, nil
+}
+
+// String 转为字符串表示，各罗盘的字符串表示以 ; 分隔
+func (puzzle *Puzzle) String() string {
+	if puzzle == nil {
+		return ""
+	}
+	strs := make([]string, len(puzzle.Compasses))
+	for i, c := range puzzle.Compasses {
+		strs[i] = c.String()
+	}
+	return strings.Join(strs, puzzleSeparator)
+}
+
+// Validate 校验谜题，谜题至少包含一个罗盘，且每个罗盘都合法
+func (puzzle *Puzzle) Validate() error {
+	if puzzle == nil || len(puzzle.Compasses) == 0 {
+		return fmt.Errorf("%w: puzzle has no compass", ErrInvalidCompass)
+	}
+	for i, c := range puzzle.Compasses {
+		if c == nil {
+			return fmt.Errorf("%w: compass %d is nil", ErrInvalidCompass, i+1)
+		}
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("compass %d validation error: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Solve 使用 solver 分别求解谜题中的每个罗盘，按罗盘的顺序返回各罗盘的解法
+// 任意一个罗盘无解时返回错误，错误中包含该罗盘的序号（从 1 开始）及其文本表示
+func (puzzle *Puzzle) Solve(ctx context.Context, solver Solver) ([]Steps, error) {
+	if err := puzzle.Validate(); err != nil {
+		return nil, fmt.Errorf("puzzle validation error: %w", err)
+	}
+	ret := make([]Steps, len(puzzle.Compasses))
+	for i, c := range puzzle.Compasses {
+		solution, err := solver.Solve(ctx, *c)
+		if err != nil {
+			return nil, fmt.Errorf("compass %d: %w", i+1, WrapSolveError(*c, err))
+		}
+		ret[i] = solution
+	}
+	return ret, nil
+}
@@ -0,0 +1,81 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestParsePuzzle 测试 ParsePuzzle 函数及 Puzzle.String 方法
+func TestParsePuzzle(t *testing.T) {
+	puzzle, err := ParsePuzzle("3+1,0-2,5+2/o,mi ; 0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedRet := "3+1,0-2,5+2/mi,o;0+1,4-4,0+2/mi,oi,om"
+	if ret := puzzle.String(); ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+
+	puzzle, err = ParsePuzzleNotches("0cw1,4ccw4,0cw2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expectedRet := "0+1,4-4,0+2/mi,oi,om"; puzzle.String() != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", puzzle.String(), expectedRet)
+	}
+
+	for _, s := range []string{"", "0+1,4-4,0+2/oi,om,mi;", "0+1,4-4,0+2/oi;0+1,4-4/oi"} {
+		if _, err := ParsePuzzle(s); !errors.Is(err, ErrParseFormat) {
+			t.Errorf("unexpected error of %#v: %v (expected: %s)", s, err, ErrParseFormat)
+		}
+	}
+}
+
+// TestPuzzleSolve 测试 Puzzle.Solve 方法
+func TestPuzzleSolve(t *testing.T) {
+	solver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new solver error: %s", err)
+	}
+
+	puzzle, err := ParsePuzzle("0+1,4-4,0+2/oi,om,mi;3+1,0-2,0+2/o")
+	if err != nil {
+		t.Fatalf("parse puzzle error: %s", err)
+	}
+	ret, err := puzzle.Solve(context.Background(), solver)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expectedRet := []string{"mi2,oi4,om2", "o3"}
+	if len(ret) != len(expectedRet) {
+		t.Fatalf("unexpected result: %v (expected: %v)", ret, expectedRet)
+	}
+	for i := range ret {
+		if ret[i].String() != expectedRet[i] {
+			t.Errorf("unexpected solution of compass %d: %s (expected: %s)", i+1, ret[i].String(), expectedRet[i])
+		}
+	}
+
+	// 任意一个罗盘无解时报错
+	puzzle, err = ParsePuzzle("0+1,4-4,0+2/oi,om,mi;3+1,1-2,0+2/o")
+	if err != nil {
+		t.Fatalf("parse puzzle error: %s", err)
+	}
+	_, err = puzzle.Solve(context.Background(), solver)
+	if !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrUnsolvable)
+	}
+	// 错误中包含无解罗盘的序号及文本表示
+	if expected := "compass 2: solve failed for 3+1,1-2,0+2/o: "; err == nil || !strings.HasPrefix(err.Error(), expected) {
+		t.Errorf("unexpected error: %v (expected to start with %#v)", err, expected)
+	}
+
+	// 空谜题不合法
+	if _, err := (&Puzzle{}).Solve(context.Background(), solver); !errors.Is(err, ErrInvalidCompass) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrInvalidCompass)
+	}
+}
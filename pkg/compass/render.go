@@ -0,0 +1,185 @@
+package compass
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// 渲染时半径每单位在水平和垂直方向上占用的字符数
+	renderScaleX = 4
+	renderScaleY = 1.6
+	// 外圈半径
+	renderOuterRadius = 3
+
+	// 彩色渲染使用的 ANSI 转义序列
+	ansiGreen = "\x1b[32m"
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// ansiColor 以 ANSI 转义序列为字符串着色
+func ansiColor(color, s string) string {
+	return color + s + ansiReset
+}
+
+// renderRing 渲染时的一个圈
+type renderRing struct {
+	// 标记指针的字符
+	mark byte
+	// 名称
+	name string
+	// 半径
+	radius int
+	ring   Ring
+}
+
+// RenderLabels 字符画下方各行的标签，可以使用其它语言的叫法（比如 "外圈" ），为空时使用 DefaultRenderLabels 中的标签
+type RenderLabels struct {
+	Outer  string
+	Middle string
+	Inner  string
+	// 圈分组一行的标签
+	Groups string
+}
+
+// DefaultRenderLabels 默认的标签
+var DefaultRenderLabels = RenderLabels{Outer: "outer", Middle: "middle", Inner: "inner", Groups: "groups"}
+
+// orDefault 返回 label ，为空时返回 defaultLabel
+func orDefault(label, defaultLabel string) string {
+	if label == "" {
+		return defaultLabel
+	}
+	return label
+}
+
+// Render 将罗盘渲染为多行字符画，仅用于展示
+// 三个同心圈从外到内分别以 O 、 M 、 I 标记指针所在位置，其余位置以 . 表示，
+// 目标位置（正左方）以 > 标记，字符画下方列出各圈的位置及旋转速度，以及圈分组
+func (compass *Compass) Render() string {
+	return compass.RenderWith(DefaultRenderLabels, false)
+}
+
+// RenderColor 同 Render ，但以 ANSI 转义序列着色，用于在终端中展示
+// 目标位置标记及位于目标位置的指针为绿色，其余指针为红色
+func (compass *Compass) RenderColor() string {
+	return compass.RenderWith(DefaultRenderLabels, true)
+}
+
+// RenderWith 同 Render ，但字符画下方各行使用给定的标签， color 为 true 时同 RenderColor 着色
+// 标签可以包含宽字符（比如中文或 emoji ），各行的位置及旋转速度按等宽终端中的显示宽度对齐
+func (compass *Compass) RenderWith(labels RenderLabels, color bool) string {
+	if compass == nil {
+		return ""
+	}
+	std := compass.standardized()
+	rings := []renderRing{
+		{mark: 'O', name: orDefault(labels.Outer, DefaultRenderLabels.Outer), radius: renderOuterRadius, ring: std.OuterRing},
+		{mark: 'M', name: orDefault(labels.Middle, DefaultRenderLabels.Middle), radius: renderOuterRadius - 1, ring: std.MiddleRing},
+		{mark: 'I', name: orDefault(labels.Inner, DefaultRenderLabels.Inner), radius: renderOuterRadius - 2, ring: std.InnerRing},
+	}
+
+	// 画布，左侧留出目标位置标记的空间
+	const marginX = 2
+	cx := marginX + renderOuterRadius*renderScaleX
+	cy := int(math.Round(renderOuterRadius * renderScaleY * math.Sin(math.Pi/3)))
+	canvas := make([][]byte, 2*cy+1)
+	for i := range canvas {
+		canvas[i] = []byte(strings.Repeat(" ", 2*cx+1-marginX))
+	}
+	for _, r := range rings {
+		for loc := 0; loc < 6; loc++ {
+			// 位置 0 为正左方，沿顺时针方向每个位置 60 度
+			angle := math.Pi - float64(loc)*math.Pi/3
+			x := cx + int(math.Round(float64(r.radius*renderScaleX)*math.Cos(angle)))
+			y := cy - int(math.Round(float64(r.radius)*renderScaleY*math.Sin(angle)))
+			if loc == r.ring.Location {
+				canvas[y][x] = r.mark
+			} else {
+				canvas[y][x] = '.'
+			}
+		}
+	}
+	canvas[cy][0] = '>'
+	canvas[cy][cx] = '+'
+
+	// 组合
+	// 各标记着色后的字符串
+	marks := map[byte]string{'>': ">"}
+	for _, r := range rings {
+		marks[r.mark] = string(r.mark)
+	}
+	if color {
+		marks['>'] = ansiColor(ansiGreen, ">")
+		for _, r := range rings {
+			if r.ring.Location == TargetLocation {
+				marks[r.mark] = ansiColor(ansiGreen, string(r.mark))
+			} else {
+				marks[r.mark] = ansiColor(ansiRed, string(r.mark))
+			}
+		}
+	}
+	b := strings.Builder{}
+	for _, line := range canvas {
+		for _, c := range []byte(strings.TrimRight(string(line), " ")) {
+			if m, ok := marks[c]; ok {
+				b.WriteString(m)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	// 各行标签按显示宽度对齐，标签中可能包含宽字符
+	groupsLabel := orDefault(labels.Groups, DefaultRenderLabels.Groups)
+	nameWidth := displayWidth(groupsLabel) - 2
+	for _, r := range rings {
+		if w := displayWidth(r.name); w > nameWidth {
+			nameWidth = w
+		}
+	}
+	for _, r := range rings {
+		fmt.Fprintf(&b, "%s %s %d%+d\n", marks[r.mark], padRight(r.name, nameWidth), r.ring.Location, r.ring.Speed)
+	}
+	rgStr := std.String()
+	fmt.Fprintf(&b, "%s %s\n", padRight(groupsLabel, nameWidth+2), rgStr[strings.Index(rgStr, "/")+1:])
+	return b.String()
+}
+
+var (
+	// emojiPointers 各位置指针方向对应的 emoji ，位置 0 为正左方，沿顺时针方向每个位置 60 度
+	emojiPointers = [6]string{"⬅️", "↖️", "↗️", "➡️", "↘️", "↙️"}
+	// emojiRings 外圈、中圈、内圈对应的 emoji
+	emojiRings = [3]string{"🔴", "🟢", "🔵"}
+)
+
+// EmojiString 转为由 emoji 组成的多行字符串表示，便于在聊天软件中展示
+// 外圈、中圈、内圈各一行，依次为圈的标记（ 🔴 、 🟢 、 🔵 ）、指针方向的箭头及旋转速度，
+// 旋转速度以方向（ 🔃 顺时针、 🔄 逆时针）加大小表示，位于目标位置的圈以 🎯 标记，最后一行为圈分组。
+// 比如 "0+1,4-4,0+2/mi,oi,om" 中圈一行为 "🟢 ↘️ 🔄4"
+func (compass *Compass) EmojiString() string {
+	if compass == nil {
+		return ""
+	}
+	std := compass.standardized()
+	b := strings.Builder{}
+	for i, r := range []Ring{std.OuterRing, std.MiddleRing, std.InnerRing} {
+		b.WriteString(emojiRings[i] + " " + emojiPointers[r.Location])
+		switch {
+		case r.Speed > 0:
+			fmt.Fprintf(&b, " 🔃%d", r.Speed)
+		case r.Speed < 0:
+			fmt.Fprintf(&b, " 🔄%d", -r.Speed)
+		}
+		if r.Location == TargetLocation {
+			b.WriteString(" 🎯")
+		}
+		b.WriteByte('\n')
+	}
+	rgStr := std.String()
+	b.WriteString(rgStr[strings.Index(rgStr, "/")+1:])
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package compass
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files in testdata")
+
+// TestCompassRender 测试 Compass.Render 方法，与 testdata/render 下的 golden 文件逐字节比较
+// 修改渲染逻辑后，可使用 go test -run TestCompassRender -update 重新生成 golden 文件
+func TestCompassRender(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		compass string
+		// 为空时使用 Render
+		labels *RenderLabels
+	}{
+		{name: "example", compass: "0+1,4-4,0+2/oi,om,mi"},
+		{name: "solved", compass: "0+1,0-4,0+2/oi"},
+		{name: "scattered", compass: "3+1,1-2,5+3/o,m,i"},
+		{name: "composite", compass: "2-1,5+2,4+1/o,mi,(o+mi)"},
+		// 标签包含中文及 emoji 时各行仍按显示宽度对齐
+		{name: "wide_labels", compass: "0+1,4-4,0+2/oi,om,mi", labels: &RenderLabels{Outer: "外圈", Middle: "中圈 🟢", Inner: "❤️", Groups: "圈分组"}},
+	} {
+		c, err := ParseCompass(tc.compass)
+		if err != nil {
+			t.Errorf("parse compass %s error: %s", tc.compass, err)
+			continue
+		}
+		ret := c.Render()
+		if tc.labels != nil {
+			ret = c.RenderWith(*tc.labels, false)
+		}
+		// 最后四行的位置及旋转速度（或圈分组）从同一列开始
+		lines := strings.Split(strings.TrimSuffix(ret, "\n"), "\n")
+		columns := map[int]bool{}
+		for _, line := range lines[len(lines)-4:] {
+			columns[displayWidth(line[:strings.LastIndex(line, " ")+1])] = true
+		}
+		if len(columns) != 1 {
+			t.Errorf("unexpected rendering of %s: labels not aligned\n%s", tc.compass, ret)
+		}
+
+		golden := filepath.Join("testdata", "render", tc.name+".golden")
+		if *update {
+			if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+				t.Fatalf("create testdata directory error: %s", err)
+			}
+			if err := os.WriteFile(golden, []byte(ret), 0o644); err != nil {
+				t.Fatalf("write golden file %s error: %s", golden, err)
+			}
+			continue
+		}
+		expectedRet, err := os.ReadFile(golden)
+		if err != nil {
+			t.Errorf("read golden file %s error: %s", golden, err)
+			continue
+		}
+		if ret != string(expectedRet) {
+			t.Errorf("unexpected rendering of %s:\n%s\nexpected (%s):\n%s", tc.compass, ret, golden, expectedRet)
+		}
+	}
+}
+
+// TestCompassRenderColor 测试 Compass.RenderColor 方法
+func TestCompassRenderColor(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	ret := c.RenderColor()
+	// 去掉着色后与 Render 一致
+	plain := strings.NewReplacer(ansiGreen, "", ansiRed, "", ansiReset, "").Replace(ret)
+	if plain != c.Render() {
+		t.Errorf("unexpected rendering without colors:\n%s\nexpected:\n%s", plain, c.Render())
+	}
+	for _, s := range []string{
+		ansiColor(ansiGreen, ">"),
+		ansiColor(ansiGreen, "O"),
+		ansiColor(ansiRed, "M"),
+		ansiColor(ansiGreen, "I"),
+	} {
+		if !strings.Contains(ret, s) {
+			t.Errorf("unexpected rendering: %#v (expected to contain: %#v)", ret, s)
+		}
+	}
+}
+
+// TestCompassEmojiString 测试 Compass.EmojiString 方法
+func TestCompassEmojiString(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: -4},
+		InnerRing:  Ring{Location: 2, Speed: 0},
+		RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleInnerRingGroup},
+	}
+	expectedRet := "🔴 ⬅️ 🔃1 🎯\n🟢 ↘️ 🔄4\n🔵 ↗️\nmi,om"
+	if ret := c.EmojiString(); ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+}
@@ -0,0 +1,114 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+// NewReusableSolver 创建一个复用内存的引航罗盘求解器
+// 求解器在罗盘状态图上广度优先搜索，返回总转动次数最少的解法（总转动次数相同时不保证与默认求解器的解法相同）。
+// 搜索使用的状态数组、队列等在多次求解间复用，适合大量求解罗盘的场景，以减少内存分配；
+// 因此求解器不能被多个 goroutine 同时使用。不支持 SolverOptions 中的 Trace 、 GroupCost 、 GroupLimits 、 Avoid 及 NoConsecutiveRepeat
+func NewReusableSolver(opts SolverOptions) (Solver, error) {
+	if opts.Trace != nil || len(opts.GroupCost) > 0 || len(opts.GroupLimits) > 0 || opts.Avoid != nil || opts.NoConsecutiveRepeat {
+		return nil, fmt.Errorf("trace, group costs, group limits, states to avoid and no consecutive repeat are not supported by reusable solver")
+	}
+	return &reusableSolver{
+		logger: opts.Logger,
+		queue:  make([]int, 0, 216),
+	}, nil
+}
+
+// reusableSolver 复用内存的引航罗盘求解器
+type reusableSolver struct {
+	logger logr.Logger
+
+	// 以下字段在每次求解时重置后复用
+	// 到达各状态的上一个状态， -1 表示未到达
+	prev [216]int
+	// 到达各状态的转动在 moves 中的下标
+	prevMove [216]int
+	// 广度优先搜索的队列
+	queue []int
+	// 可以转动的圈分组及复合圈分组，顺序同 Compass.moves
+	moves []Step
+	// 解法中各转动的次数
+	counts []int
+}
+
+var _ Solver = &reusableSolver{}
+
+// Solve 求解引航罗盘
+func (s *reusableSolver) Solve(ctx context.Context, compass Compass) (Steps, error) {
+	// 校验入参
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if err := compass.PreSolveCheck(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// 重置复用的内存
+	std := compass.standardized()
+	s.moves = s.moves[:0]
+	for _, rg := range std.RingGroups {
+		s.moves = append(s.moves, Step{RingGroup: rg, Count: 1})
+	}
+	for _, composite := range std.CompositeGroups {
+		s.moves = append(s.moves, Step{Composite: composite, Count: 1})
+	}
+	for i := range s.prev {
+		s.prev[i] = -1
+	}
+	start := compass.Hash()
+	s.prev[start] = start
+	s.queue = append(s.queue[:0], start)
+
+	// 广度优先搜索
+	for head := 0; head < len(s.queue) && s.prev[targetHash] < 0; head++ {
+		cur := s.queue[head]
+		for i := range s.moves {
+			next := rotateHashStep(std, cur, &s.moves[i])
+			if s.prev[next] < 0 {
+				s.prev[next], s.prevMove[next] = cur, i
+				s.queue = append(s.queue, next)
+			}
+		}
+	}
+	if s.prev[targetHash] < 0 {
+		if err := compass.Solvability(); err != nil {
+			return nil, err
+		}
+		return nil, ErrUnsolvable
+	}
+
+	// 回溯，按转动统计次数，转动已按标准化顺序排列，因此结果是标准化的
+	if cap(s.counts) < len(s.moves) {
+		s.counts = make([]int, len(s.moves))
+	}
+	s.counts = s.counts[:len(s.moves)]
+	for i := range s.counts {
+		s.counts[i] = 0
+	}
+	dials := 0
+	for cur := targetHash; cur != start; cur = s.prev[cur] {
+		if s.counts[s.prevMove[cur]] == 0 {
+			dials++
+		}
+		s.counts[s.prevMove[cur]]++
+	}
+	solution := make(Steps, 0, dials)
+	for i, count := range s.counts {
+		if count > 0 {
+			step := s.moves[i]
+			step.Count = count
+			solution = append(solution, step)
+		}
+	}
+	return solution, nil
+}
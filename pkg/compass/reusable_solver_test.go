@@ -0,0 +1,107 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// reusableSolverTestCompasses 测试复用内存的求解器使用的罗盘，即各圈分组下所有 216 个状态
+func reusableSolverTestCompasses() []Compass {
+	var ret []Compass
+	for _, c := range []Compass{
+		{
+			OuterRing:  Ring{Speed: 1},
+			MiddleRing: Ring{Speed: -4},
+			InnerRing:  Ring{Speed: 2},
+			RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+		},
+		{
+			OuterRing:       Ring{Speed: -1},
+			MiddleRing:      Ring{Speed: 2},
+			InnerRing:       Ring{Speed: 1},
+			RingGroups:      []RingGroup{OuterRingGroup, MiddleInnerRingGroup},
+			CompositeGroups: [][]RingGroup{{OuterRingGroup, MiddleInnerRingGroup}},
+		},
+	} {
+		for hash := 0; hash < 216; hash++ {
+			c := *c.Clone()
+			c.OuterRing.Location, c.MiddleRing.Location, c.InnerRing.Location = hash/36, hash/6%6, hash%6
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// TestReusableSolver 测试复用内存的求解器，同一个求解器多次求解的结果都是最少转动次数的解法
+func TestReusableSolver(t *testing.T) {
+	solver, err := NewReusableSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new reusable solver error: %s", err)
+	}
+	solved := &Compass{}
+	for _, c := range reusableSolverTestCompasses() {
+		ret, err := solver.Solve(context.Background(), c)
+		distance := c.DistanceTo(solved)
+		if distance < 0 {
+			if !errors.Is(err, ErrUnsolvable) {
+				t.Errorf("unexpected error of %s: %v (expected: %s)", c.String(), err, ErrUnsolvable)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error of %s: %s", c.String(), err)
+			continue
+		}
+		if ok, _ := CheckSolution(c, ret); !ok {
+			t.Errorf("unexpected result of %s: %s (not a solution)", c.String(), ret.String())
+		}
+		if ret.TotalCount() != distance {
+			t.Errorf("unexpected moves of %s: %d (expected: %d)", c.String(), ret.TotalCount(), distance)
+		}
+		if ret.String() != ret.Standardize().String() || len(ret) != len(ret.Standardize()) {
+			t.Errorf("unexpected result of %s: %v (expected standardized)", c.String(), ret)
+		}
+	}
+
+	for name, opts := range map[string]SolverOptions{
+		"trace":                 {Trace: io.Discard},
+		"group costs":           {GroupCost: map[RingGroup]int{OuterRingGroup: 2}},
+		"group limits":          {GroupLimits: map[RingGroup]int{OuterRingGroup: 1}},
+		"states to avoid":       {Avoid: func(*Compass) bool { return false }},
+		"no consecutive repeat": {NoConsecutiveRepeat: true},
+	} {
+		if _, err := NewReusableSolver(opts); err == nil {
+			t.Errorf("expected error with %s", name)
+		}
+	}
+}
+
+// BenchmarkDefaultSolverBatch 基准测试默认求解器批量求解，每次求解都重新分配内存
+func BenchmarkDefaultSolverBatch(b *testing.B) {
+	benchmarkSolverBatch(b, NewDefaultSolver)
+}
+
+// BenchmarkReusableSolverBatch 基准测试复用内存的求解器批量求解，与 BenchmarkDefaultSolverBatch 比较
+func BenchmarkReusableSolverBatch(b *testing.B) {
+	benchmarkSolverBatch(b, NewReusableSolver)
+}
+
+// benchmarkSolverBatch 基准测试使用同一个求解器依次求解所有测试罗盘
+func benchmarkSolverBatch(b *testing.B, newSolver func(SolverOptions) (Solver, error)) {
+	compasses := reusableSolverTestCompasses()
+	solver, err := newSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		b.Fatalf("new solver error: %s", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range compasses {
+			_, _ = solver.Solve(context.Background(), c)
+		}
+	}
+}
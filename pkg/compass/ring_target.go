@@ -0,0 +1,136 @@
+package compass
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SolveRingTo 返回只把指定圈转到位置 loc 所需的最少转动的步骤，其余圈的位置不限
+// ring 必须是单个圈组成的圈分组，即 OuterRingGroup 、 MiddleRingGroup 或 InnerRingGroup ， loc 的有效范围是 0-5 。
+// 返回的步骤是标准化的，该圈无法转到 loc 时返回包装了 ErrUnsolvable 的错误
+func (compass *Compass) SolveRingTo(ring RingGroup, loc int) (Steps, error) {
+	if compass == nil {
+		return nil, fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	if ringIndex(ring) < 0 {
+		return nil, fmt.Errorf("invalid ring: %s (must be one of [o m i])", ring.ShortName())
+	}
+	if loc < 0 || loc > 5 {
+		return nil, fmt.Errorf("invalid location: %d (must be 0-5)", loc)
+	}
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+
+	var target [3]*int
+	target[ringIndex(ring)] = &loc
+	if steps, ok := compass.searchPattern(target); ok {
+		return steps, nil
+	}
+	return nil, fmt.Errorf(
+		"%w: %s ring can only reach locations %v, which do not include location %d",
+		ErrUnsolvable, strings.ToLower(ring.Name()), compass.ReachableLocations(ring), loc,
+	)
+}
+
+// SolvePattern 返回把罗盘转到符合 target 的状态所需的最少转动的步骤
+// target 依次为外圈、中圈、内圈的目标位置，为 nil 的圈位置不限，比如外圈和内圈位于 0 、中圈任意。
+// 各圈都为 TargetLocation 时即求解罗盘，只有一个圈非 nil 时即 SolveRingTo 。
+// 返回的步骤是标准化的，无法转到符合 target 的状态时返回包装了 ErrUnsolvable 的错误
+func (compass *Compass) SolvePattern(target [3]*int) (Steps, error) {
+	if compass == nil {
+		return nil, fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	for i, loc := range target {
+		if loc != nil && (*loc < 0 || *loc > 5) {
+			return nil, fmt.Errorf("invalid location of %s ring: %d (must be 0-5)", ringNames[i], *loc)
+		}
+	}
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if steps, ok := compass.searchPattern(target); ok {
+		return steps, nil
+	}
+	return nil, fmt.Errorf("%w: no reachable state matches the target %s", ErrUnsolvable, PatternString(target))
+}
+
+// PatternString 返回 SolvePattern 的目标的字符串表示，依次为外圈、中圈、内圈的位置，位置不限的圈为 "_" ，比如 "0,_,0"
+func PatternString(target [3]*int) string {
+	strs := make([]string, 3)
+	for i, loc := range target {
+		strs[i] = "_"
+		if loc != nil {
+			strs[i] = strconv.Itoa(*loc)
+		}
+	}
+	return strings.Join(strs, ",")
+}
+
+// MatchesPattern 判断罗盘当前的状态是否符合 target ，参见 SolvePattern ； compass 为 nil 时返回 false
+func (compass *Compass) MatchesPattern(target [3]*int) bool {
+	if compass == nil {
+		return false
+	}
+	return matchesPattern(compass.Hash(), target)
+}
+
+// matchesPattern 判断 hash 表示的状态是否符合 target ，参见 Compass.Hash
+func matchesPattern(hash int, target [3]*int) bool {
+	for i, loc := range [3]int{hash / 36, hash / 6 % 6, hash % 6} {
+		if target[i] != nil && *target[i] != loc {
+			return false
+		}
+	}
+	return true
+}
+
+// ringNames 外圈、中圈、内圈的名称
+var ringNames = [3]string{"outer", "middle", "inner"}
+
+// ringIndex 返回圈在 Compass.Hash 等处的下标，即外圈为 0 、中圈为 1 、内圈为 2 ，不是单个圈时返回 -1
+func ringIndex(ring RingGroup) int {
+	switch ring {
+	case OuterRingGroup:
+		return 0
+	case MiddleRingGroup:
+		return 1
+	case InnerRingGroup:
+		return 2
+	}
+	return -1
+}
+
+// searchPattern 广度优先搜索把罗盘转到符合 target 的状态所需的最少转动的步骤，没有时返回 false
+func (compass *Compass) searchPattern(target [3]*int) (Steps, bool) {
+	// 记录到达各状态的上一个状态及转动
+	moves := compass.moves()
+	var prev, prevMove [216]int
+	for i := range prev {
+		prev[i] = -1
+	}
+	start := compass.Hash()
+	prev[start] = start
+	queue := []int{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if !matchesPattern(cur, target) {
+			for i := range moves {
+				next := rotateHashStep(compass, cur, &moves[i])
+				if prev[next] < 0 {
+					prev[next], prevMove[next] = cur, i
+					queue = append(queue, next)
+				}
+			}
+			continue
+		}
+		var steps Steps
+		for ; cur != start; cur = prev[cur] {
+			steps = append(steps, moves[prevMove[cur]])
+		}
+		return steps.Standardize(), true
+	}
+	return nil, false
+}
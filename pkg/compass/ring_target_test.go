@@ -0,0 +1,134 @@
+package compass
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCompassSolveRingTo 测试 Compass.SolveRingTo 方法
+func TestCompassSolveRingTo(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	cases := []struct {
+		ring        RingGroup
+		loc         int
+		expectedRet string
+	}{
+		// 已在该位置
+		{ring: OuterRingGroup, loc: 0, expectedRet: ""},
+		{ring: OuterRingGroup, loc: 2, expectedRet: "oi2"},
+		{ring: MiddleRingGroup, loc: 0, expectedRet: "mi1"},
+		{ring: InnerRingGroup, loc: 4, expectedRet: "mi2"},
+	}
+	for _, tc := range cases {
+		ret, err := c.SolveRingTo(tc.ring, tc.loc)
+		if err != nil {
+			t.Errorf("unexpected error of %s to %d: %s", tc.ring.Name(), tc.loc, err)
+			continue
+		}
+		if ret.String() != tc.expectedRet {
+			t.Errorf("unexpected result of %s to %d: %#v (expected: %#v)", tc.ring.Name(), tc.loc, ret.String(), tc.expectedRet)
+		}
+	}
+
+	// 中圈只能到达偶数位置
+	if _, err := c.SolveRingTo(MiddleRingGroup, 3); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrUnsolvable)
+	}
+	// 参数不合法
+	if _, err := c.SolveRingTo(OuterMiddleRingGroup, 0); err == nil {
+		t.Errorf("expected error with ring group of multiple rings")
+	}
+	if _, err := c.SolveRingTo(OuterRingGroup, 6); err == nil {
+		t.Errorf("expected error with invalid location")
+	}
+}
+
+// TestCompassSolvePattern 测试 Compass.SolvePattern 方法
+func TestCompassSolvePattern(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	loc := func(l int) *int { return &l }
+	cases := []struct {
+		target        [3]*int
+		expectedMoves int
+	}{
+		// 位置不限
+		{target: [3]*int{}, expectedMoves: 0},
+		// 即求解罗盘
+		{target: [3]*int{loc(0), loc(0), loc(0)}, expectedMoves: 8},
+		// 外圈和内圈位于 0 ，中圈任意
+		{target: [3]*int{loc(0), nil, loc(0)}, expectedMoves: 0},
+		{target: [3]*int{loc(2), nil, loc(2)}, expectedMoves: 2},
+		// 即 SolveRingTo
+		{target: [3]*int{nil, loc(0), nil}, expectedMoves: 1},
+	}
+	for _, tc := range cases {
+		ret, err := c.SolvePattern(tc.target)
+		if err != nil {
+			t.Errorf("unexpected error of %s: %s", PatternString(tc.target), err)
+			continue
+		}
+		if ret.TotalCount() != tc.expectedMoves {
+			t.Errorf("unexpected moves of %s: %s (expected: %d moves)", PatternString(tc.target), ret.String(), tc.expectedMoves)
+		}
+		// 转动后符合目标
+		cur := c.Clone()
+		if err := cur.ApplySteps(ret); err != nil {
+			t.Fatalf("apply steps error: %s", err)
+		}
+		for i, l := range [3]int{cur.OuterRing.Location, cur.MiddleRing.Location, cur.InnerRing.Location} {
+			if tc.target[i] != nil && *tc.target[i] != l {
+				t.Errorf("unexpected state of %s after %s: %s", PatternString(tc.target), ret.String(), cur.String())
+				break
+			}
+		}
+	}
+
+	// 中圈只能到达偶数位置
+	if _, err := c.SolvePattern([3]*int{nil, loc(3), nil}); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrUnsolvable)
+	}
+	// 参数不合法
+	if _, err := c.SolvePattern([3]*int{loc(6), nil, nil}); err == nil {
+		t.Errorf("expected error with invalid location")
+	}
+}
+
+// TestCompassMatchesPattern 测试 Compass.MatchesPattern 方法
+func TestCompassMatchesPattern(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	loc := func(l int) *int { return &l }
+	for _, tc := range []struct {
+		target   [3]*int
+		expected bool
+	}{
+		{target: [3]*int{}, expected: true},
+		{target: [3]*int{loc(0), nil, loc(0)}, expected: true},
+		{target: [3]*int{loc(0), loc(4), loc(0)}, expected: true},
+		{target: [3]*int{loc(0), loc(0), loc(0)}, expected: false},
+	} {
+		if ret := c.MatchesPattern(tc.target); ret != tc.expected {
+			t.Errorf("unexpected result of %s: %t (expected: %t)", PatternString(tc.target), ret, tc.expected)
+		}
+	}
+	var nilCompass *Compass
+	if nilCompass.MatchesPattern([3]*int{}) {
+		t.Errorf("unexpected result of nil compass: true (expected: false)")
+	}
+}
+
+// TestPatternString 测试 PatternString
+func TestPatternString(t *testing.T) {
+	zero := 0
+	if ret := PatternString([3]*int{&zero, nil, &zero}); ret != "0,_,0" {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, "0,_,0")
+	}
+}
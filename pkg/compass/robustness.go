@@ -0,0 +1,63 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+)
+
+// RobustnessReport 返回解法对读错一个圈位置的稳健性报告，用于判断对罗盘的转录有多大把握
+// 依次将每个圈的位置加减 1 ，检查原罗盘的解法是否仍然有效，无效时给出扰动后罗盘的解法（或无解）。
+// 报告第一行是原罗盘的解法，之后每行对应一种扰动，比如
+//
+//	solution: mi2,oi4,om2
+//	outer 0 -> 5 (5+1,4-4,0+2/mi,oi,om): solution fails, use om1
+//	middle 4 -> 3 (0+1,3-4,0+2/mi,oi,om): solution fails, unsolvable (...)
+func (compass *Compass) RobustnessReport() string {
+	if compass == nil {
+		return ""
+	}
+	solver := &defaultSolver{logger: logr.Discard()}
+	solve := func(c *Compass) string {
+		solution, err := solver.Solve(context.Background(), *c)
+		if err != nil {
+			return fmt.Sprintf("unsolvable (%s)", err)
+		}
+		return "use " + solution.String()
+	}
+
+	var b strings.Builder
+	solution, err := solver.Solve(context.Background(), *compass)
+	if err != nil {
+		fmt.Fprintf(&b, "solution: unsolvable (%s)\n", err)
+	} else {
+		fmt.Fprintf(&b, "solution: %s\n", solution.String())
+	}
+	for _, r := range []struct {
+		name string
+		ring func(c *Compass) *Ring
+	}{
+		{name: "outer", ring: func(c *Compass) *Ring { return &c.OuterRing }},
+		{name: "middle", ring: func(c *Compass) *Ring { return &c.MiddleRing }},
+		{name: "inner", ring: func(c *Compass) *Ring { return &c.InnerRing }},
+	} {
+		for _, delta := range []int{-1, 1} {
+			perturbed := compass.Clone()
+			ring := r.ring(perturbed)
+			from := (ring.Location%6 + 6) % 6
+			ring.Location = ((from+delta)%6 + 6) % 6
+			fmt.Fprintf(&b, "%s %d -> %d (%s): ", r.name, from, ring.Location, perturbed.String())
+			switch ok, _ := CheckSolution(*perturbed, solution); {
+			case err == nil && ok:
+				b.WriteString("solution still works\n")
+			case err == nil:
+				fmt.Fprintf(&b, "solution fails, %s\n", solve(perturbed))
+			default:
+				fmt.Fprintf(&b, "%s\n", solve(perturbed))
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
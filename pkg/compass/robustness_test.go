@@ -0,0 +1,49 @@
+package compass
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCompassRobustnessReport 测试 Compass.RobustnessReport 方法
+func TestCompassRobustnessReport(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	lines := strings.Split(c.RobustnessReport(), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("unexpected lines: %d (expected: %d)", len(lines), 7)
+	}
+	if expected := "solution: mi2,oi4,om2"; lines[0] != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", lines[0], expected)
+	}
+	for i, prefix := range []string{
+		"outer 0 -> 5 (5+1,4-4,0+2/mi,oi,om): ",
+		"outer 0 -> 1 (1+1,4-4,0+2/mi,oi,om): ",
+		"middle 4 -> 3 (0+1,3-4,0+2/mi,oi,om): ",
+		"middle 4 -> 5 (0+1,5-4,0+2/mi,oi,om): ",
+		"inner 0 -> 5 (0+1,4-4,5+2/mi,oi,om): ",
+		"inner 0 -> 1 (0+1,4-4,1+2/mi,oi,om): ",
+	} {
+		if !strings.HasPrefix(lines[i+1], prefix) {
+			t.Errorf("unexpected result: %#v (expected prefix: %#v)", lines[i+1], prefix)
+		}
+	}
+	if expected := "outer 0 -> 5 (5+1,4-4,0+2/mi,oi,om): solution fails, use om1"; lines[1] != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", lines[1], expected)
+	}
+	if !strings.Contains(lines[3], "solution fails, unsolvable (") {
+		t.Errorf("unexpected result: %#v (expected unsolvable)", lines[3])
+	}
+
+	// 原罗盘无解
+	c.MiddleRing.Location = 3
+	lines = strings.Split(c.RobustnessReport(), "\n")
+	if !strings.HasPrefix(lines[0], "solution: unsolvable (") {
+		t.Errorf("unexpected result: %#v (expected unsolvable)", lines[0])
+	}
+	if expected := "middle 3 -> 4 (0+1,4-4,0+2/mi,oi,om): use mi2,oi4,om2"; lines[4] != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", lines[4], expected)
+	}
+}
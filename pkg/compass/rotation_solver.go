@@ -0,0 +1,76 @@
+package compass
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+)
+
+// NewRotationSolver 创建一个最小转动角度引航罗盘求解器
+// 求解器返回各圈转过的总角度最小的解法：每点击一次，各圈转过 |位移|×60 度（位移即圈的旋转速度，或 GroupEffect 中指定的值，
+// 与 Standardize 一致对 6 取模，比如旋转速度 +7 与 +1 相同），
+// 所以单次点击移动较多的圈分组代价更高。支持 SolverOptions.Avoid 和 SolverOptions.NoConsecutiveRepeat ，不支持 GroupCost 和 GroupLimits
+func NewRotationSolver(opts SolverOptions) (Solver, error) {
+	if len(opts.GroupCost) > 0 {
+		return nil, fmt.Errorf("group cost is not supported by rotation solver")
+	}
+	if len(opts.GroupLimits) > 0 {
+		return nil, fmt.Errorf("group limits are not supported by rotation solver")
+	}
+	return &minCostSolver{
+		logger:   opts.Logger,
+		avoid:    opts.Avoid,
+		noRepeat: opts.NoConsecutiveRepeat,
+		stepCost: rotationCost,
+	}, nil
+}
+
+// SolveMinRotation 求解各圈转过的总角度最小的解法，参见 NewRotationSolver
+func (compass *Compass) SolveMinRotation() (Steps, error) {
+	if compass == nil {
+		return nil, fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	solver, err := NewRotationSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		return nil, err
+	}
+	return solver.Solve(context.Background(), *compass)
+}
+
+// Rotation 返回按 steps 转动罗盘时各圈转过的总角度（单位为 60 度），参见 NewRotationSolver
+// 罗盘为 nil 时返回 0
+func (compass *Compass) Rotation(steps Steps) int {
+	if compass == nil {
+		return 0
+	}
+	total := 0
+	for i := range steps {
+		total += steps[i].Count * rotationCost(compass, &steps[i])
+	}
+	return total
+}
+
+// rotationCost 返回按步骤转动一次时各圈转过的总角度（单位为 60 度），忽略步骤的转动次数
+// 各圈分组的位移先对 6 取模；复合圈分组中的各圈分组同时转动，同一个圈的位移再相加
+func rotationCost(compass *Compass, step *Step) int {
+	rgs := []RingGroup{step.RingGroup}
+	if step.IsComposite() {
+		rgs = step.Composite
+	}
+	var effect [3]int
+	for _, rg := range rgs {
+		e := compass.groupEffect(rg)
+		for i := range effect {
+			effect[i] += e[i] % 6
+		}
+	}
+	total := 0
+	for _, e := range effect {
+		if e < 0 {
+			e = -e
+		}
+		total += e
+	}
+	return total
+}
@@ -0,0 +1,67 @@
+package compass
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// TestCompassSolveMinRotation 测试 Compass.SolveMinRotation 方法
+func TestCompassSolveMinRotation(t *testing.T) {
+	// 外圈每次转动 180 度，总转动次数最少的 oi5 转过 (3+1)×5=20 ，多点击一次的 mi5,om1 只转过 (1+1)×5+(3+1)×1=14
+	c := Compass{
+		OuterRing:  Ring{Location: 3, Speed: 3},
+		MiddleRing: Ring{Location: 0, Speed: 1},
+		InnerRing:  Ring{Location: 5, Speed: -1},
+		RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+	}
+	defaultSolver, err := NewDefaultSolver(SolverOptions{Logger: logr.Discard()})
+	if err != nil {
+		t.Fatalf("new default solver error: %s", err)
+	}
+	defaultRet, err := defaultSolver.Solve(context.Background(), c)
+	if err != nil {
+		t.Fatalf("compass solve error: %s", err)
+	}
+	if defaultRet.String() != "oi5" || c.Rotation(defaultRet) != 20 {
+		t.Errorf("unexpected result of default solver: %s, rotation %d (expected: oi5, rotation 20)", defaultRet.String(), c.Rotation(defaultRet))
+	}
+
+	ret, err := c.SolveMinRotation()
+	if err != nil {
+		t.Fatalf("compass solve error: %s", err)
+	}
+	if ret.String() != "mi5,om1" || c.Rotation(ret) != 14 {
+		t.Errorf("unexpected result: %s, rotation %d (expected: mi5,om1, rotation 14)", ret.String(), c.Rotation(ret))
+	}
+	if ok, err := CheckSolution(c, ret); err != nil || !ok {
+		t.Errorf("unexpected check result of %s: %t, %v (expected: true)", ret.String(), ok, err)
+	}
+
+	// 不支持代价及转动次数限制
+	if _, err := NewRotationSolver(SolverOptions{GroupCost: map[RingGroup]int{OuterRingGroup: 2}}); err == nil {
+		t.Errorf("unexpected result with group cost: no error (expected an error)")
+	}
+	if _, err := NewRotationSolver(SolverOptions{GroupLimits: map[RingGroup]int{OuterRingGroup: 2}}); err == nil {
+		t.Errorf("unexpected result with group limits: no error (expected an error)")
+	}
+
+	// 旋转速度超过一周时与标准化后的罗盘相同
+	fast := c
+	fast.OuterRing.Speed = 9
+	fast.InnerRing.Speed = -7
+	if ret := fast.Rotation(ret); ret != 14 {
+		t.Errorf("unexpected rotation with speeds beyond a full turn: %d (expected: 14)", ret)
+	}
+	if ret, err := fast.SolveMinRotation(); err != nil || ret.String() != "mi5,om1" {
+		t.Errorf("unexpected result with speeds beyond a full turn: %s, %v (expected: mi5,om1)", ret.String(), err)
+	}
+
+	// 罗盘为 nil
+	var nilCompass *Compass
+	if _, err := nilCompass.SolveMinRotation(); !errors.Is(err, ErrInvalidCompass) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrInvalidCompass)
+	}
+}
@@ -0,0 +1,39 @@
+package compass
+
+import (
+	"sync"
+)
+
+// SafeCompass 并发安全的罗盘
+// 包装一个 Compass ，所有读写均由互斥锁保护
+type SafeCompass struct {
+	mu      sync.Mutex
+	compass *Compass
+}
+
+// NewSafeCompass 创建一个并发安全的罗盘
+// 包装的是 compass 的拷贝，之后对 compass 的修改不会影响 SafeCompass
+func NewSafeCompass(compass *Compass) *SafeCompass {
+	return &SafeCompass{compass: compass.Clone()}
+}
+
+// Rotate 按圈分组转动一次罗盘，参见 Compass.Rotate
+func (sc *SafeCompass) Rotate(rg RingGroup) error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.compass.Rotate(rg)
+}
+
+// Snapshot 返回当前罗盘的拷贝
+func (sc *SafeCompass) Snapshot() *Compass {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.compass.Clone()
+}
+
+// IsSolved 判断罗盘是否已解决，参见 Compass.IsSolved
+func (sc *SafeCompass) IsSolved() bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.compass.IsSolved()
+}
@@ -0,0 +1,46 @@
+package compass
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestSafeCompass 测试 SafeCompass 的并发访问，使用 -race 运行以检查数据竞争
+func TestSafeCompass(t *testing.T) {
+	sc := NewSafeCompass(&Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleInnerRingGroup},
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 6; j++ {
+				if err := sc.Rotate(OuterRingGroup); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+				if err := sc.Rotate(MiddleInnerRingGroup); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 6; j++ {
+				snapshot := sc.Snapshot()
+				snapshot.OuterRing.Location = 3
+				_ = sc.IsSolved()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 每个圈都转了 36 次，回到目标位置，且对快照的修改不影响原罗盘
+	if !sc.IsSolved() {
+		t.Errorf("unexpected result: %s (expected to be solved)", sc.Snapshot())
+	}
+}
@@ -0,0 +1,110 @@
+package compass
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+const (
+	// dailyScrambleMoves 每日罗盘打乱的转动次数
+	dailyScrambleMoves = 20
+	// dailyMaxAttempts 每日罗盘打乱的最大尝试次数，避免打乱后恰好回到目标位置
+	dailyMaxAttempts = 16
+)
+
+// allRingGroups 所有合法的圈分组
+var allRingGroups = []RingGroup{
+	InnerRingGroup,
+	MiddleRingGroup,
+	MiddleInnerRingGroup,
+	OuterRingGroup,
+	OuterInnerRingGroup,
+	OuterMiddleRingGroup,
+}
+
+// Scramble 随机打乱罗盘
+// 从罗盘当前状态开始，随机选择罗盘支持的圈分组转动 moves 次，返回打乱后的罗盘，不修改原罗盘。
+// 每个圈分组转动 6 次都会回到原位，因此从已解决的状态打乱得到的罗盘一定有解。
+// moves 为 0 时返回原罗盘的拷贝，即从已解决的状态打乱时得到的仍是已解决的罗盘； moves 为负数时返回错误
+func Scramble(rng *rand.Rand, compass *Compass, moves int) (*Compass, error) {
+	if moves < 0 {
+		return nil, fmt.Errorf("invalid scramble moves: %d (must not be negative)", moves)
+	}
+	if err := compass.Validate(); err != nil {
+		return nil, fmt.Errorf("compass validation error: %w", err)
+	}
+	if moves > 0 && len(compass.RingGroups) == 0 {
+		return nil, fmt.Errorf("compass has no ring group to scramble with")
+	}
+	ret := compass.Clone()
+	for i := 0; i < moves; i++ {
+		if err := ret.Rotate(ret.RingGroups[rng.Intn(len(ret.RingGroups))]); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// ScrambleDepth 返回从已解决的状态（各圈位于目标位置，旋转速度及圈分组与罗盘相同）打乱得到罗盘当前状态所需的最少转动次数
+// 即 Scramble 至少需要转动多少次才能得到当前状态。
+// 因为只能朝一个方向转动，打乱深度与求解所需的最少转动次数不一定相同，比如外圈转动 1 次打乱的罗盘需要再转动 5 次才能解开，
+// 但从已解决的状态出发可以到达的状态也都可以转回已解决的状态，因此罗盘无解时返回包装了 ErrUnsolvable 的错误
+func (compass *Compass) ScrambleDepth() (int, error) {
+	if err := compass.Validate(); err != nil {
+		return -1, fmt.Errorf("compass validation error: %w", err)
+	}
+	solved := compassAtHash(compass, targetHash)
+	depth := solved.DistanceTo(compass)
+	if depth < 0 {
+		return -1, fmt.Errorf("%w: the state can not be scrambled from the solved state", ErrUnsolvable)
+	}
+	return depth, nil
+}
+
+// DailyCompass 返回指定日期的每日罗盘
+// 以日期（ 2006-01-02 格式，忽略时间及时区）为随机数种子，同一天总是得到相同的罗盘。
+// 各圈的旋转速度随机生成，圈分组为 groups ，未指定时随机选择 3 个；
+// 罗盘从已解决的状态打乱得到，因此一定有解，且打乱后不会恰好在已解决的状态
+func DailyCompass(date time.Time, groups ...RingGroup) *Compass {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(date.Format("2006-01-02")))
+	rng := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	// 随机旋转速度，范围是 -4 到 4 ，不为 0
+	randomSpeed := func() int {
+		speed := rng.Intn(4) + 1
+		if rng.Intn(2) == 0 {
+			return -speed
+		}
+		return speed
+	}
+	solved := &Compass{
+		OuterRing:  Ring{Location: TargetLocation, Speed: randomSpeed()},
+		MiddleRing: Ring{Location: TargetLocation, Speed: randomSpeed()},
+		InnerRing:  Ring{Location: TargetLocation, Speed: randomSpeed()},
+		RingGroups: groups,
+	}
+	if len(solved.RingGroups) == 0 {
+		perm := rng.Perm(len(allRingGroups))
+		for _, i := range perm[:3] {
+			solved.RingGroups = append(solved.RingGroups, allRingGroups[i])
+		}
+	}
+
+	// 打乱
+	ret := solved
+	for i := 0; i < dailyMaxAttempts; i++ {
+		scrambled, err := Scramble(rng, solved, dailyScrambleMoves)
+		if err != nil {
+			// 圈分组不合法，无法打乱
+			return solved
+		}
+		ret = scrambled
+		if !ret.IsSolved() {
+			break
+		}
+	}
+	return ret.Standardize()
+}
@@ -0,0 +1,118 @@
+package compass
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// TestScramble 测试 Scramble
+func TestScramble(t *testing.T) {
+	solved := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		scrambled, err := Scramble(rng, solved, i)
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+			return
+		}
+		if err := scrambled.Solvability(); err != nil {
+			t.Errorf("expected scrambled compass %s to be solvable: %s", scrambled, err)
+		}
+	}
+	if !solved.IsSolved() {
+		t.Errorf("expected original compass not to be modified: %s", solved)
+	}
+
+	// 转动 0 次得到已解决的罗盘，转动 1 次得到打乱深度为 1 的罗盘
+	scrambled, err := Scramble(rng, solved, 0)
+	if err != nil || !scrambled.IsSolved() || scrambled == solved {
+		t.Errorf("unexpected result of 0 moves: %s, %v (expected a solved copy)", scrambled, err)
+	}
+	scrambled, err = Scramble(rng, solved, 1)
+	if err != nil || scrambled.IsSolved() {
+		t.Errorf("unexpected result of 1 move: %s, %v (expected an unsolved compass)", scrambled, err)
+	}
+	if depth, err := scrambled.ScrambleDepth(); err != nil || depth != 1 {
+		t.Errorf("unexpected scramble depth of %s scrambled by 1 move: %d, %v (expected: 1)", scrambled, depth, err)
+	}
+	// 转动次数不能为负数
+	if _, err := Scramble(rng, solved, -1); err == nil {
+		t.Errorf("unexpected result of -1 moves: no error (expected an error)")
+	}
+	// 没有圈分组时只能转动 0 次
+	empty := &Compass{}
+	if _, err := Scramble(rng, empty, 0); err != nil {
+		t.Errorf("unexpected error of 0 moves without ring groups: %s", err)
+	}
+	if _, err := Scramble(rng, empty, 1); err == nil {
+		t.Errorf("unexpected result of 1 move without ring groups: no error (expected an error)")
+	}
+}
+
+// TestCompassScrambleDepth 测试 Compass.ScrambleDepth ，打乱 N 次得到的罗盘的打乱深度不超过 N
+func TestCompassScrambleDepth(t *testing.T) {
+	solved := &Compass{
+		OuterRing:  Ring{Location: 0, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: -4},
+		InnerRing:  Ring{Location: 0, Speed: 2},
+		RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		scrambled, err := Scramble(rng, solved, i)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		depth, err := scrambled.ScrambleDepth()
+		if err != nil || depth > i {
+			t.Errorf("unexpected scramble depth of %s scrambled by %d moves: %d, %v (expected: at most %d)", scrambled, i, depth, err, i)
+		}
+	}
+
+	// 只能朝一个方向转动，打乱深度与求解所需的转动次数不同
+	c := &Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: 1},
+		InnerRing:  Ring{Location: 0, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup},
+	}
+	if depth, err := c.ScrambleDepth(); err != nil || depth != 1 {
+		t.Errorf("unexpected scramble depth of %s: %d, %v (expected: 1)", c, depth, err)
+	}
+
+	// 无解
+	c.OuterRing.Speed = 2
+	if _, err := c.ScrambleDepth(); !errors.Is(err, ErrUnsolvable) {
+		t.Errorf("unexpected error of %s: %v (expected: %s)", c, err, ErrUnsolvable)
+	}
+}
+
+// TestDailyCompass 测试 DailyCompass
+func TestDailyCompass(t *testing.T) {
+	date := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	a := DailyCompass(date)
+	// 同一天的不同时间得到相同的罗盘
+	b := DailyCompass(date.Add(12 * time.Hour))
+	if !a.Equal(b) {
+		t.Errorf("expected daily compasses of the same day to be equal: %s, %s", a, b)
+	}
+	if a.IsSolved() {
+		t.Errorf("expected daily compass not to be solved: %s", a)
+	}
+	if err := a.Solvability(); err != nil {
+		t.Errorf("expected daily compass %s to be solvable: %s", a, err)
+	}
+
+	// 指定圈分组
+	c := DailyCompass(date, OuterRingGroup, MiddleInnerRingGroup)
+	if rgs := c.Standardize().RingGroups; len(rgs) != 2 || rgs[0] != MiddleInnerRingGroup || rgs[1] != OuterRingGroup {
+		t.Errorf("unexpected ring groups of daily compass %s (expected: %v)", c, []RingGroup{MiddleInnerRingGroup, OuterRingGroup})
+	}
+}
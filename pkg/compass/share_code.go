@@ -0,0 +1,80 @@
+package compass
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// shareCodeAlphabet 分享码字母表，只包含 URL 安全的字符
+	shareCodeAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_"
+	// shareCodeMaxCount 分享码中单个字符可以表示的最大转动次数
+	shareCodeMaxCount = 9
+)
+
+// shareCodeGroups 分享码中各圈分组的序号
+var shareCodeGroups = []RingGroup{
+	OuterRingGroup,
+	MiddleRingGroup,
+	InnerRingGroup,
+	OuterMiddleRingGroup,
+	OuterInnerRingGroup,
+	MiddleInnerRingGroup,
+}
+
+// EncodeSolution 将解法编码为便于分享的短码
+// 标准化后的每个步骤编码为一个字符（圈分组序号 * 10 + 转动次数），
+// 转动次数超过 9 次的步骤拆分为多个字符。可以使用 DecodeSolution 解码。
+// 转动复合圈分组的步骤按其中各圈分组分别转动编码，效果不变但解码后不再是复合圈分组
+func EncodeSolution(steps Steps) string {
+	var expanded Steps
+	for _, s := range steps {
+		if !s.IsComposite() {
+			expanded = append(expanded, s)
+			continue
+		}
+		for _, rg := range s.Composite {
+			expanded = append(expanded, Step{RingGroup: rg, Count: s.Count})
+		}
+	}
+
+	b := strings.Builder{}
+	for _, s := range expanded.Standardize() {
+		idx := shareCodeGroupIndex(s.RingGroup)
+		if idx < 0 {
+			continue
+		}
+		for count := s.Count; count > 0; count -= shareCodeMaxCount {
+			n := count
+			if n > shareCodeMaxCount {
+				n = shareCodeMaxCount
+			}
+			b.WriteByte(shareCodeAlphabet[idx*(shareCodeMaxCount+1)+n])
+		}
+	}
+	return b.String()
+}
+
+// DecodeSolution 解码 EncodeSolution 编码的短码
+func DecodeSolution(code string) (Steps, error) {
+	var steps Steps
+	for i, c := range code {
+		n := strings.IndexRune(shareCodeAlphabet, c)
+		idx, count := n/(shareCodeMaxCount+1), n%(shareCodeMaxCount+1)
+		if n < 0 || idx >= len(shareCodeGroups) || count == 0 {
+			return nil, fmt.Errorf("%w: unknown character in share code at index %d: %q", ErrParseFormat, i, c)
+		}
+		steps = append(steps, Step{RingGroup: shareCodeGroups[idx], Count: count})
+	}
+	return steps.Standardize(), nil
+}
+
+// shareCodeGroupIndex 返回圈分组在分享码中的序号，不合法的圈分组返回 -1
+func shareCodeGroupIndex(rg RingGroup) int {
+	for i, g := range shareCodeGroups {
+		if g == rg {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,45 @@
+package compass
+
+import (
+	"testing"
+)
+
+// TestShareCode 测试 EncodeSolution 和 DecodeSolution
+func TestShareCode(t *testing.T) {
+	for _, tc := range []struct {
+		steps        Steps
+		expectedCode string
+	}{
+		{steps: nil, expectedCode: ""},
+		{
+			steps: Steps{
+				{RingGroup: MiddleInnerRingGroup, Count: 2},
+				{RingGroup: OuterInnerRingGroup, Count: 4},
+				{RingGroup: OuterMiddleRingGroup, Count: 2},
+			},
+			expectedCode: "qiW",
+		},
+		// 转动次数超过单个字符的表示范围
+		{steps: Steps{{RingGroup: InnerRingGroup, Count: 12}}, expectedCode: "TN"},
+	} {
+		code := EncodeSolution(tc.steps)
+		if code != tc.expectedCode {
+			t.Errorf("unexpected code of %s: %#v (expected: %#v)", tc.steps, code, tc.expectedCode)
+		}
+		decoded, err := DecodeSolution(code)
+		if err != nil {
+			t.Errorf("unexpected error decoding %#v: %s", code, err)
+			continue
+		}
+		if decoded.String() != tc.steps.String() {
+			t.Errorf("unexpected result decoding %#v: %#v (expected: %#v)", code, decoded.String(), tc.steps.String())
+		}
+	}
+
+	// 非法的短码
+	for _, code := range []string{"0", "_", "q!"} {
+		if _, err := DecodeSolution(code); err == nil {
+			t.Errorf("expected error decoding %#v", code)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package compass
+
+import (
+	"sort"
+)
+
+// AllSolutions 返回罗盘所有总转动次数最少的转动序列，即状态图中从当前状态到目标状态的所有最短路径
+// 序列中每个步骤转动一次，转动顺序不同的序列视为不同的解法，因此数量可能非常多，
+// limit 大于 0 时至多返回 limit 个，找到足够的解法后即停止搜索，总数可以通过 CountSolutions 得到。
+// 解法按每一步的圈分组依次比较排序，圈分组按标准化顺序、复合圈分组在最后。
+// 罗盘无解时返回 nil ，已解开时返回一个空序列
+func (compass *Compass) AllSolutions(limit int) []Steps {
+	if compass == nil {
+		return nil
+	}
+	moves := compass.moves()
+	toTarget := distancesToTarget(compass, moves)
+	start := compass.Hash()
+	if toTarget[start] < 0 {
+		return nil
+	}
+
+	// 沿每一步都使剩余转动次数减少 1 的边深度优先搜索
+	var ret []Steps
+	var path Steps
+	var walk func(cur int) bool
+	walk = func(cur int) bool {
+		if cur == targetHash {
+			solution := make(Steps, len(path))
+			copy(solution, path)
+			ret = append(ret, solution)
+			return limit <= 0 || len(ret) < limit
+		}
+		for i := range moves {
+			next := rotateHashStep(compass, cur, &moves[i])
+			if toTarget[next] != toTarget[cur]-1 {
+				continue
+			}
+			path = append(path, moves[i])
+			more := walk(next)
+			path = path[:len(path)-1]
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	walk(start)
+	return ret
+}
+
+// CountSolutions 返回 AllSolutions 的结果的个数，即总转动次数最少的转动序列的个数，罗盘无解时返回 0
+// 按剩余转动次数从少到多逐层累加各状态到目标状态的最短路径数，不需要逐个枚举
+func (compass *Compass) CountSolutions() int {
+	if compass == nil {
+		return 0
+	}
+	moves := compass.moves()
+	toTarget := distancesToTarget(compass, moves)
+	start := compass.Hash()
+	if toTarget[start] < 0 {
+		return 0
+	}
+
+	var ways [216]int
+	ways[targetHash] = 1
+	for depth := 1; depth <= toTarget[start]; depth++ {
+		for hash := range toTarget {
+			if toTarget[hash] != depth {
+				continue
+			}
+			for i := range moves {
+				if next := rotateHashStep(compass, hash, &moves[i]); toTarget[next] == depth-1 {
+					ways[hash] += ways[next]
+				}
+			}
+		}
+	}
+	return ways[start]
+}
+
+// distancesToTarget 返回各状态到目标状态的最少转动次数，无法到达目标状态的为 -1
+// 从目标状态沿反向边广度优先搜索
+func distancesToTarget(compass *Compass, moves []Step) [216]int {
+	reverse := make([][]int, 216)
+	for hash := 0; hash < 216; hash++ {
+		for i := range moves {
+			next := rotateHashStep(compass, hash, &moves[i])
+			reverse[next] = append(reverse[next], hash)
+		}
+	}
+	var toTarget [216]int
+	for i := range toTarget {
+		toTarget[i] = -1
+	}
+	toTarget[targetHash] = 0
+	queue := []int{targetHash}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, prev := range reverse[cur] {
+			if toTarget[prev] < 0 {
+				toTarget[prev] = toTarget[cur] + 1
+				queue = append(queue, prev)
+			}
+		}
+	}
+	return toTarget
+}
+
+// CanonicalSolutions 返回罗盘所有总转动次数最少、且作为转动的多重集合互不相同的解法
+// 各圈分组的转动可以交换顺序，因此只有转动顺序不同的 AllSolutions 实际上是同一个解法，
+// 返回的结果即 AllSolutions 按 Steps.Standardize 去重后的结果，各解法都是标准化的，按字符串表示升序排列。
+// 罗盘无解时返回 nil
+func (compass *Compass) CanonicalSolutions() []Steps {
+	if compass == nil {
+		return nil
+	}
+	// 直接枚举各圈分组（及复合圈分组）的转动次数，避免枚举所有转动顺序
+	var ret []Steps
+	minimal := -1
+	for _, solution := range (&defaultSolver{}).getPossibleSolutions(*compass) {
+		if minimal >= 0 && solution.TotalCount() > minimal {
+			break
+		}
+		if ok, _ := CheckSolution(*compass, solution); ok {
+			minimal = solution.TotalCount()
+			ret = append(ret, solution.Standardize())
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].String() < ret[j].String()
+	})
+	return ret
+}
@@ -0,0 +1,118 @@
+package compass
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCompassAllSolutions 测试 Compass.AllSolutions 方法
+func TestCompassAllSolutions(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 5, Speed: 1},
+		MiddleRing: Ring{Location: 4, Speed: 1},
+		RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup},
+	}
+	// 外圈转 1 次、中圈转 2 次，共 3 种顺序
+	ret := c.AllSolutions(0)
+	if len(ret) != 3 {
+		t.Errorf("unexpected number of solutions: %d (expected: 3)", len(ret))
+	}
+	for _, solution := range ret {
+		if ok, err := CheckSolution(*c, solution); err != nil || !ok {
+			t.Errorf("unexpected check result of %s: %t, %v (expected: true)", solution, ok, err)
+		}
+		if solution.TotalCount() != 3 {
+			t.Errorf("unexpected moves of %s: %d (expected: 3)", solution, solution.TotalCount())
+		}
+	}
+	if ret := c.AllSolutions(2); len(ret) != 2 {
+		t.Errorf("unexpected number of limited solutions: %d (expected: 2)", len(ret))
+	}
+	if ret := c.CountSolutions(); ret != 3 {
+		t.Errorf("unexpected count of solutions: %d (expected: 3)", ret)
+	}
+
+	// 已解开及无解
+	solved := &Compass{RingGroups: []RingGroup{OuterRingGroup}}
+	if ret := solved.AllSolutions(0); !reflect.DeepEqual(ret, []Steps{{}}) {
+		t.Errorf("unexpected result of solved compass: %#v (expected: %#v)", ret, []Steps{{}})
+	}
+	unsolvable := &Compass{OuterRing: Ring{Location: 1, Speed: 2}, RingGroups: []RingGroup{OuterRingGroup}}
+	if ret := unsolvable.AllSolutions(0); ret != nil {
+		t.Errorf("unexpected result of unsolvable compass: %#v (expected: nil)", ret)
+	}
+	if ret := solved.CountSolutions(); ret != 1 {
+		t.Errorf("unexpected count of solutions of solved compass: %d (expected: 1)", ret)
+	}
+	if ret := unsolvable.CountSolutions(); ret != 0 {
+		t.Errorf("unexpected count of solutions of unsolvable compass: %d (expected: 0)", ret)
+	}
+}
+
+// TestCompassCountSolutions 测试 Compass.CountSolutions 与 AllSolutions 的结果个数一致
+func TestCompassCountSolutions(t *testing.T) {
+	c, err := ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	for outer := 0; outer < 6; outer++ {
+		for middle := 0; middle < 6; middle++ {
+			c.OuterRing.Location, c.MiddleRing.Location = outer, middle
+			if ret, expected := c.CountSolutions(), len(c.AllSolutions(0)); ret != expected {
+				t.Errorf("unexpected count of solutions of %s: %d (expected: %d)", c.String(), ret, expected)
+			}
+		}
+	}
+}
+
+// TestCompassCanonicalSolutions 测试 Compass.CanonicalSolutions 方法
+func TestCompassCanonicalSolutions(t *testing.T) {
+	cases := []struct {
+		compass     Compass
+		expectedRet []string
+	}{
+		// 只有转动顺序不同的解法合并为一个
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 5, Speed: 1},
+				MiddleRing: Ring{Location: 4, Speed: 1},
+				RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup},
+			},
+			expectedRet: []string{"m2,o1"},
+		},
+		// 两个不同的解法
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 0, Speed: 1},
+				MiddleRing: Ring{Location: 4, Speed: -4},
+				InnerRing:  Ring{Location: 0, Speed: 2},
+				RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+			},
+			expectedRet: []string{"mi2,oi1,om5", "mi2,oi4,om2"},
+		},
+	}
+	for _, tc := range cases {
+		ret := tc.compass.CanonicalSolutions()
+		var strs []string
+		for _, solution := range ret {
+			strs = append(strs, solution.String())
+		}
+		if !reflect.DeepEqual(strs, tc.expectedRet) {
+			t.Errorf("unexpected result of %s: %#v (expected: %#v)", tc.compass.String(), strs, tc.expectedRet)
+		}
+
+		// 与 AllSolutions 去重后的结果相同
+		distinct := map[string]bool{}
+		for _, solution := range tc.compass.AllSolutions(0) {
+			distinct[solution.Standardize().String()] = true
+		}
+		if len(distinct) != len(ret) {
+			t.Errorf("unexpected number of distinct solutions of %s: %d (expected: %d)", tc.compass.String(), len(distinct), len(ret))
+		}
+		for _, s := range strs {
+			if !distinct[s] {
+				t.Errorf("solution %s of %s not found in all solutions", s, tc.compass.String())
+			}
+		}
+	}
+}
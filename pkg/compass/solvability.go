@@ -0,0 +1,272 @@
+package compass
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReachableLocations 返回指定圈仅考虑自身时可以到达的所有位置（升序）
+// ring 必须是单个圈组成的圈分组，即 OuterRingGroup 、 MiddleRingGroup 或 InnerRingGroup ，否则返回 nil 。
+// 该圈可以从初始位置以各圈分组转动一次时该圈的位移为步长转到任意次，
+// 因此忽略各圈之间的约束时，可到达的位置只和这些位移与 6 的最大公约数有关
+func (compass *Compass) ReachableLocations(ring RingGroup) []int {
+	if compass == nil {
+		return nil
+	}
+	var r Ring
+	var index int
+	switch ring {
+	case OuterRingGroup:
+		r, index = compass.OuterRing, 0
+	case MiddleRingGroup:
+		r, index = compass.MiddleRing, 1
+	case InnerRingGroup:
+		r, index = compass.InnerRing, 2
+	default:
+		return nil
+	}
+	start := (r.Location%6 + 6) % 6
+
+	// 当前罗盘中会转动该圈的各圈分组的位移与 6 的最大公约数
+	step := 6
+	for _, rg := range compass.RingGroups {
+		if rg&ring > 0 {
+			step = gcd(step, compass.groupEffect(rg)[index])
+		}
+	}
+	if step == 6 {
+		return []int{start}
+	}
+
+	var ret []int
+	for loc := start % step; loc < 6; loc += step {
+		ret = append(ret, loc)
+	}
+	return ret
+}
+
+// PreSolveCheck 求解前的快速检查，发现罗盘显然无解时返回包装了 ErrUnsolvable 的错误
+// 目前检查不在目标位置的圈是否至少被一个当前罗盘支持的圈分组转动，
+// 这通常是记录罗盘时漏记了圈分组导致的
+func (compass *Compass) PreSolveCheck() error {
+	if compass == nil {
+		return fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+	for _, r := range []struct {
+		rg   RingGroup
+		ring Ring
+	}{
+		{rg: OuterRingGroup, ring: compass.OuterRing},
+		{rg: MiddleRingGroup, ring: compass.MiddleRing},
+		{rg: InnerRingGroup, ring: compass.InnerRing},
+	} {
+		if (r.ring.Location%6+6)%6 == TargetLocation {
+			continue
+		}
+		covered := false
+		for _, rg := range compass.RingGroups {
+			if rg&r.rg > 0 {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return fmt.Errorf("%w: %s ring off-target but no supported group rotates it", ErrUnsolvable, strings.ToLower(r.rg.Name()))
+		}
+	}
+	return nil
+}
+
+// Solvability 判断罗盘是否有解，无解时返回说明原因的错误，错误包装了 ErrUnsolvable
+// 如果某个圈仅考虑自身就无法转到目标位置，错误会指出是哪个圈
+func (compass *Compass) Solvability() error {
+	if compass == nil {
+		return fmt.Errorf("%w: compass is nil", ErrInvalidCompass)
+	}
+
+	// 逐个圈检查是否能单独转到目标位置
+	for _, ring := range []RingGroup{OuterRingGroup, MiddleRingGroup, InnerRingGroup} {
+		reachable := compass.ReachableLocations(ring)
+		if !containsInt(reachable, TargetLocation) {
+			return fmt.Errorf(
+				"%w: %s ring can only reach locations %v, which do not include the target location %d",
+				ErrUnsolvable, strings.ToLower(ring.Name()), reachable, TargetLocation,
+			)
+		}
+	}
+
+	// 各圈单独都能转到目标位置，检查能否同时转到目标位置
+	var visited [216]bool
+	queue := []int{compass.Hash()}
+	visited[queue[0]] = true
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == targetHash {
+			return nil
+		}
+		for _, rg := range compass.RingGroups {
+			next := rotateHash(compass, cur, rg)
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return fmt.Errorf("%w: each ring can reach the target location alone, but not all at the same time", ErrUnsolvable)
+}
+
+// DistanceTo 返回把罗盘各圈转到 other 各圈位置所需的最少转动次数，无法转到时返回 -1
+// 使用当前罗盘的旋转速度、圈分组及复合圈分组（每次转动复合圈分组计为一次），忽略 other 的旋转速度和圈分组。
+// 因为只能朝一个方向转动，结果不一定对称，比如 DistanceTo(solved) 是求解所需的转动次数
+func (compass *Compass) DistanceTo(other *Compass) int {
+	if compass == nil || other == nil {
+		return -1
+	}
+	target := other.Hash()
+	moves := compass.moves()
+
+	var dist [216]int
+	for i := range dist {
+		dist[i] = -1
+	}
+	queue := []int{compass.Hash()}
+	dist[queue[0]] = 0
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == target {
+			return dist[cur]
+		}
+		for i := range moves {
+			next := rotateHashStep(compass, cur, &moves[i])
+			if dist[next] < 0 {
+				dist[next] = dist[cur] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return -1
+}
+
+// Nearest 返回罗盘可以到达的、离目标状态最近的状态，以及到达该状态的最少转动的步骤和该状态离目标状态的距离
+// 距离为各圈位置离目标位置 TargetLocation 的距离（沿任一方向转到目标位置所需的位置数，即 0-3 ）之和，
+// 距离相同时选择转动次数最少的。罗盘有解时即返回目标状态、最少转动次数的解法及距离 0
+func (compass *Compass) Nearest() (*Compass, Steps, int) {
+	if compass == nil {
+		return nil, nil, -1
+	}
+	moves := compass.moves()
+	distance := func(hash int) int {
+		d := 0
+		for _, loc := range []int{hash / 36, hash / 6 % 6, hash % 6} {
+			loc = (loc - TargetLocation + 6) % 6
+			if loc > 3 {
+				loc = 6 - loc
+			}
+			d += loc
+		}
+		return d
+	}
+
+	// 广度优先搜索，按转动次数从少到多遍历所有可到达的状态，记录到达各状态的上一个状态及转动
+	var prev, prevMove [216]int
+	for i := range prev {
+		prev[i] = -1
+	}
+	start := compass.Hash()
+	prev[start] = start
+	best := start
+	queue := []int{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if distance(cur) < distance(best) {
+			best = cur
+		}
+		for i := range moves {
+			next := rotateHashStep(compass, cur, &moves[i])
+			if prev[next] < 0 {
+				prev[next], prevMove[next] = cur, i
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	var steps Steps
+	for cur := best; cur != start; cur = prev[cur] {
+		steps = append(steps, moves[prevMove[cur]])
+	}
+	return compassAtHash(compass, best), steps.Standardize(), distance(best)
+}
+
+// CriticalGroups 返回罗盘中必不可少的圈分组（按标准化顺序），即去掉其中任意一个后罗盘都会变得无解
+// 罗盘本身无解时返回 nil 。
+// 复合圈分组只是其成员的组合，不会扩大可到达的状态，因此去掉圈分组时会一并去掉包含它的复合圈分组
+func (compass *Compass) CriticalGroups() []RingGroup {
+	if compass == nil || compass.Solvability() != nil {
+		return nil
+	}
+	std := compass.standardized()
+
+	var ret []RingGroup
+	for i, rg := range std.RingGroups {
+		reduced := Compass{
+			OuterRing:   std.OuterRing,
+			MiddleRing:  std.MiddleRing,
+			InnerRing:   std.InnerRing,
+			RingGroups:  make([]RingGroup, 0, len(std.RingGroups)-1),
+			GroupEffect: std.GroupEffect,
+		}
+		reduced.RingGroups = append(reduced.RingGroups, std.RingGroups[:i]...)
+		reduced.RingGroups = append(reduced.RingGroups, std.RingGroups[i+1:]...)
+		if reduced.Solvability() != nil {
+			ret = append(ret, rg)
+		}
+	}
+	return ret
+}
+
+// SolvableWithExtraGroup 返回一个当前罗盘不支持、但添加后罗盘即有解的圈分组
+// 罗盘无解通常是记录罗盘时漏记了圈分组，该方法依次尝试添加各个不支持的圈分组（按标准化顺序），返回第一个使罗盘有解的圈分组。
+// 罗盘本身有解，或添加任何一个圈分组都无解时返回 0 和 false
+func (compass *Compass) SolvableWithExtraGroup() (RingGroup, bool) {
+	if compass == nil || compass.Solvability() == nil {
+		return 0, false
+	}
+	for _, rg := range allRingGroups {
+		if compass.IsRingGroupSupported(rg) {
+			continue
+		}
+		extended := compass.Clone()
+		extended.RingGroups = append(extended.RingGroups, rg)
+		if extended.Solvability() == nil {
+			return rg, true
+		}
+	}
+	return 0, false
+}
+
+// containsInt 判断 s 中是否包含 v
+func containsInt(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// gcd 返回两个整数绝对值的最大公约数
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	if b < 0 {
+		b = -b
+	}
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
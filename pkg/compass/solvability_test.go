@@ -0,0 +1,331 @@
+package compass
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestCompassReachableLocations 测试 Compass.ReachableLocations 方法
+func TestCompassReachableLocations(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 1, Speed: 2},
+		MiddleRing: Ring{Location: 4, Speed: -3},
+		InnerRing:  Ring{Location: 2, Speed: 1},
+		RingGroups: []RingGroup{OuterMiddleRingGroup},
+	}
+	cases := []struct {
+		ring        RingGroup
+		expectedRet []int
+	}{
+		// 速度为 2 时只能到达奇偶性相同的位置
+		{ring: OuterRingGroup, expectedRet: []int{1, 3, 5}},
+		// 速度为 3 时只能到达相对的位置
+		{ring: MiddleRingGroup, expectedRet: []int{1, 4}},
+		// 没有圈分组转动该圈
+		{ring: InnerRingGroup, expectedRet: []int{2}},
+		// 非单个圈
+		{ring: OuterMiddleRingGroup, expectedRet: nil},
+	}
+	for _, tc := range cases {
+		ret := c.ReachableLocations(tc.ring)
+		if !reflect.DeepEqual(ret, tc.expectedRet) {
+			t.Errorf("unexpected result of %s ring: %#v (expected: %#v)", tc.ring.Name(), ret, tc.expectedRet)
+		}
+	}
+}
+
+// TestCompassSolvability 测试 Compass.Solvability 方法
+func TestCompassSolvability(t *testing.T) {
+	cases := []struct {
+		compass  Compass
+		solvable bool
+	}{
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 0, Speed: 1},
+				MiddleRing: Ring{Location: 4, Speed: -4},
+				InnerRing:  Ring{Location: 0, Speed: 2},
+				RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+			},
+			solvable: true,
+		},
+		// 外圈单独无法到达目标位置
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 3, Speed: 2},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterRingGroup},
+			},
+			solvable: false,
+		},
+		// 各圈单独可以到达目标位置，但无法同时到达
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 1, Speed: 1},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterMiddleRingGroup},
+			},
+			solvable: false,
+		},
+	}
+	for _, tc := range cases {
+		err := tc.compass.Solvability()
+		if (err == nil) != tc.solvable {
+			t.Errorf("unexpected solvability of %s: %v (expected solvable: %t)", tc.compass.String(), err, tc.solvable)
+		}
+	}
+}
+
+// TestCompassPreSolveCheck 测试 Compass.PreSolveCheck 方法
+func TestCompassPreSolveCheck(t *testing.T) {
+	c := &Compass{
+		OuterRing:  Ring{Location: 1, Speed: 1},
+		MiddleRing: Ring{Location: 0, Speed: 1},
+		InnerRing:  Ring{Location: 2, Speed: 1},
+		RingGroups: []RingGroup{OuterMiddleRingGroup},
+	}
+	err := c.PreSolveCheck()
+	expectedErr := "the compass has no solution: inner ring off-target but no supported group rotates it"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("unexpected error: %v (expected: %s)", err, expectedErr)
+	}
+
+	// 不在目标位置的圈都有圈分组转动
+	c.RingGroups = append(c.RingGroups, InnerRingGroup)
+	if err := c.PreSolveCheck(); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+// TestCompassCriticalGroups 测试 Compass.CriticalGroups 方法
+func TestCompassCriticalGroups(t *testing.T) {
+	cases := []struct {
+		compass     Compass
+		expectedRet []RingGroup
+	}{
+		// 三个圈分组两两组合都能解出，没有必不可少的圈分组
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 0, Speed: 1},
+				MiddleRing: Ring{Location: 3, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+			},
+			expectedRet: nil,
+		},
+		// 只有内圈分组能转动内圈
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 2, Speed: 1},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 3, Speed: 1},
+				RingGroups: []RingGroup{OuterMiddleRingGroup, InnerRingGroup, MiddleRingGroup},
+			},
+			expectedRet: []RingGroup{InnerRingGroup, MiddleRingGroup, OuterMiddleRingGroup},
+		},
+		// 已经解开的罗盘不需要任何圈分组
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 0, Speed: 1},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterRingGroup},
+			},
+			expectedRet: nil,
+		},
+		// 无解的罗盘
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 3, Speed: 2},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterRingGroup},
+			},
+			expectedRet: nil,
+		},
+	}
+	for _, tc := range cases {
+		ret := tc.compass.CriticalGroups()
+		if !reflect.DeepEqual(ret, tc.expectedRet) {
+			t.Errorf("unexpected result of %s: %#v (expected: %#v)", tc.compass.String(), ret, tc.expectedRet)
+		}
+	}
+}
+
+// TestCompassSolvableWithExtraGroup 测试 Compass.SolvableWithExtraGroup 方法
+func TestCompassSolvableWithExtraGroup(t *testing.T) {
+	cases := []struct {
+		compass     Compass
+		expectedRet RingGroup
+		expectedOk  bool
+	}{
+		// 漏记了能转动内圈的圈分组
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 2, Speed: 1},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 3, Speed: 1},
+				RingGroups: []RingGroup{OuterMiddleRingGroup, MiddleRingGroup},
+			},
+			expectedRet: InnerRingGroup,
+			expectedOk:  true,
+		},
+		// 外圈和中圈需要分开转动
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 1, Speed: 1},
+				MiddleRing: Ring{Location: 2, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterMiddleRingGroup},
+			},
+			expectedRet: MiddleRingGroup,
+			expectedOk:  true,
+		},
+		// 本身有解
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 1, Speed: 1},
+				MiddleRing: Ring{Location: 1, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 1},
+				RingGroups: []RingGroup{OuterMiddleRingGroup},
+			},
+			expectedRet: 0,
+			expectedOk:  false,
+		},
+		// 外圈只能到达奇数位置，添加圈分组也无法解开
+		{
+			compass: Compass{
+				OuterRing:  Ring{Location: 1, Speed: 2},
+				MiddleRing: Ring{Location: 0, Speed: 1},
+				InnerRing:  Ring{Location: 0, Speed: 2},
+				RingGroups: []RingGroup{OuterRingGroup},
+			},
+			expectedRet: 0,
+			expectedOk:  false,
+		},
+	}
+	for _, tc := range cases {
+		ret, ok := tc.compass.SolvableWithExtraGroup()
+		if ret != tc.expectedRet || ok != tc.expectedOk {
+			t.Errorf("unexpected result of %s: %s, %t (expected: %s, %t)", tc.compass.String(), ret.ShortName(), ok, tc.expectedRet.ShortName(), tc.expectedOk)
+		}
+	}
+}
+
+// TestCompassDistanceTo 测试 Compass.DistanceTo 方法
+func TestCompassDistanceTo(t *testing.T) {
+	solved := &Compass{}
+	cases := []struct {
+		from        Compass
+		to          *Compass
+		expectedRet int
+	}{
+		{
+			from: Compass{
+				OuterRing:  Ring{Location: 0, Speed: 1},
+				MiddleRing: Ring{Location: 4, Speed: -4},
+				InnerRing:  Ring{Location: 0, Speed: 2},
+				RingGroups: []RingGroup{OuterInnerRingGroup, OuterMiddleRingGroup, MiddleInnerRingGroup},
+			},
+			to:          solved,
+			expectedRet: 8,
+		},
+		// 与自身的距离为 0
+		{
+			from: Compass{
+				OuterRing:  Ring{Location: 2, Speed: 1},
+				RingGroups: []RingGroup{OuterRingGroup},
+			},
+			to:          &Compass{OuterRing: Ring{Location: 2}},
+			expectedRet: 0,
+		},
+		// 只能顺时针转动，距离不对称
+		{
+			from:        Compass{OuterRing: Ring{Location: 0, Speed: 1}, RingGroups: []RingGroup{OuterRingGroup}},
+			to:          &Compass{OuterRing: Ring{Location: 1}},
+			expectedRet: 1,
+		},
+		{
+			from:        Compass{OuterRing: Ring{Location: 1, Speed: 1}, RingGroups: []RingGroup{OuterRingGroup}},
+			to:          &Compass{OuterRing: Ring{Location: 0}},
+			expectedRet: 5,
+		},
+		// 转半圈，距离对称
+		{
+			from:        Compass{OuterRing: Ring{Location: 0, Speed: 3}, RingGroups: []RingGroup{OuterRingGroup}},
+			to:          &Compass{OuterRing: Ring{Location: 3}},
+			expectedRet: 1,
+		},
+		{
+			from:        Compass{OuterRing: Ring{Location: 3, Speed: 3}, RingGroups: []RingGroup{OuterRingGroup}},
+			to:          &Compass{OuterRing: Ring{Location: 0}},
+			expectedRet: 1,
+		},
+		// 复合圈分组计为一次转动
+		{
+			from: Compass{
+				OuterRing:       Ring{Location: 5, Speed: 1},
+				MiddleRing:      Ring{Location: 5, Speed: 1},
+				RingGroups:      []RingGroup{OuterRingGroup, MiddleRingGroup},
+				CompositeGroups: [][]RingGroup{{OuterRingGroup, MiddleRingGroup}},
+			},
+			to:          solved,
+			expectedRet: 1,
+		},
+		// 无法转到
+		{
+			from:        Compass{OuterRing: Ring{Location: 0, Speed: 2}, RingGroups: []RingGroup{OuterRingGroup}},
+			to:          &Compass{OuterRing: Ring{Location: 1}},
+			expectedRet: -1,
+		},
+	}
+	for _, tc := range cases {
+		ret := tc.from.DistanceTo(tc.to)
+		if ret != tc.expectedRet {
+			t.Errorf("unexpected result of %s to %s: %d (expected: %d)", tc.from.String(), tc.to.String(), ret, tc.expectedRet)
+		}
+	}
+}
+
+// TestCompassNearest 测试 Compass.Nearest 方法
+func TestCompassNearest(t *testing.T) {
+	cases := []struct {
+		compass          string
+		expectedNearest  string
+		expectedSteps    string
+		expectedDistance int
+	}{
+		// 有解时即为解法
+		{compass: "0+1,4-4,0+2/oi,om,mi", expectedNearest: "0+1,0-4,0+2/mi,oi,om", expectedSteps: "mi2,oi4,om2", expectedDistance: 0},
+		// 外圈只能到达 1 、 3 、 5 ，不转动时距离已最小
+		{compass: "1+2,0+1,0+1/o", expectedNearest: "1+2,0+1,0+1/o", expectedSteps: "", expectedDistance: 1},
+		{compass: "3+2,0+1,0+1/o", expectedNearest: "5+2,0+1,0+1/o", expectedSteps: "o1", expectedDistance: 1},
+	}
+	for _, c := range cases {
+		input, err := ParseCompass(c.compass)
+		if err != nil {
+			t.Errorf("parse compass %s error: %s", c.compass, err)
+			continue
+		}
+		nearest, steps, distance := input.Nearest()
+		if nearest.String() != c.expectedNearest || steps.String() != c.expectedSteps || distance != c.expectedDistance {
+			t.Errorf(
+				"unexpected result of %s: %s, %#v, %d (expected: %s, %#v, %d)",
+				c.compass, nearest.String(), steps.String(), distance,
+				c.expectedNearest, c.expectedSteps, c.expectedDistance,
+			)
+			continue
+		}
+		// 按步骤转动后即到达该状态
+		moved := input.Clone()
+		if err := moved.ApplySteps(steps); err != nil {
+			t.Errorf("apply steps of %s error: %s", c.compass, err)
+			continue
+		}
+		if moved.Hash() != nearest.Hash() {
+			t.Errorf("unexpected state after steps of %s: %s (expected: %s)", c.compass, moved.String(), nearest.String())
+		}
+	}
+}
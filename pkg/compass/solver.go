@@ -2,6 +2,7 @@ package compass
 
 import (
 	"context"
+	"io"
 
 	"github.com/go-logr/logr"
 )
@@ -16,4 +17,23 @@ type Solver interface {
 type SolverOptions struct {
 	// 日志记录器
 	Logger logr.Logger
+	// 搜索过程跟踪输出
+	// 非空时以 Graphviz DOT 格式输出搜索过程中展开的每个状态及状态间的转移，仅用于调试
+	Trace io.Writer
+	// 各圈分组转动一次的代价
+	// 仅对 NewMinCostSolver 创建的求解器有效，未指定的圈分组代价为 1
+	GroupCost map[RingGroup]int
+	// 各圈分组的最大转动次数
+	// 转动复合圈分组时，其中每个圈分组都计一次转动；未指定的圈分组不限制转动次数。
+	// 仅对 NewDefaultSolver 和 NewBalancedSolver 创建的求解器有效
+	GroupLimits map[RingGroup]int
+	// 需要避开的状态
+	// 非空时求解器不会转到使其返回 true 的状态（包括目标状态，不包括初始状态），此时转动的顺序会影响结果，
+	// 返回的解法保持转动顺序，只合并相邻的相同步骤，参见 Steps.OrderedString 。
+	// 仅对 NewMinCostSolver 和 NewRotationSolver 创建的求解器有效
+	Avoid func(*Compass) bool
+	// 是否禁止连续两次转动相同的圈分组（或复合圈分组）
+	// 为 true 时解法中每个步骤只转动一次，且相邻的步骤互不相同，此时转动的顺序会影响结果，返回的解法保持转动顺序。
+	// 仅对 NewMinCostSolver 和 NewRotationSolver 创建的求解器有效
+	NoConsecutiveRepeat bool
 }
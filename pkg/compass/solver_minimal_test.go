@@ -0,0 +1,42 @@
+package compass_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+	"github.com/keybrl/hksr-compass/pkg/compass/compasstest"
+)
+
+// TestSolversMinimal 测试所有以最少转动次数为目标的求解器在所有可解罗盘上都返回转动次数最少的解法
+func TestSolversMinimal(t *testing.T) {
+	rgs := []compass.RingGroup{compass.OuterInnerRingGroup, compass.OuterMiddleRingGroup, compass.MiddleInnerRingGroup}
+	speeds := [3]int{1, -4, 2}
+	for name, newSolver := range map[string]func(compass.SolverOptions) (compass.Solver, error){
+		"default":  compass.NewDefaultSolver,
+		"balanced": compass.NewBalancedSolver,
+		// 各圈分组代价相同时最少代价即最少转动次数
+		"min cost": compass.NewMinCostSolver,
+	} {
+		solver, err := newSolver(compass.SolverOptions{Logger: logr.Discard()})
+		if err != nil {
+			t.Fatalf("new %s solver error: %s", name, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		enumerated, err := compass.Enumerate(ctx, solver, rgs, speeds)
+		cancel()
+		if err != nil {
+			t.Errorf("enumerate with %s solver error: %s", name, err)
+			continue
+		}
+		for _, e := range enumerated {
+			e := e
+			if !compasstest.AssertMinimal(t, &e.Compass, e.Solution) {
+				t.Errorf("%s solver returned non-minimal solution", name)
+			}
+		}
+	}
+}
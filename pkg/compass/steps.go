@@ -10,16 +10,44 @@ import (
 type Step struct {
 	// 转动的圈分组
 	RingGroup RingGroup
+	// 同时转动的复合圈分组
+	// 非空时表示转动的是复合圈分组，此时忽略 RingGroup ，参见 Compass.CompositeGroups
+	Composite []RingGroup
 	// 转动次数
 	Count int
 }
 
 // String 转为字符串表示
+// 转动复合圈分组时，复合圈分组以括号包围，比如 "(mi+o)2"
 func (step *Step) String() string {
 	if step == nil || step.Count <= 0 {
 		return ""
 	}
-	return fmt.Sprintf("%s%d", step.RingGroup.ShortName(), step.Count)
+	return fmt.Sprintf("%s%d", step.key(), step.Count)
+}
+
+// IsComposite 判断是否是转动复合圈分组的步骤
+func (step *Step) IsComposite() bool {
+	return step != nil && len(step.Composite) > 0
+}
+
+// key 返回步骤转动的圈分组或复合圈分组的字符串表示
+func (step *Step) key() string {
+	if step.IsComposite() {
+		return "(" + compositeKey(step.Composite) + ")"
+	}
+	return step.RingGroup.ShortName()
+}
+
+// less 判断步骤的排序，圈分组按值升序排在前，复合圈分组按字符串表示升序排在后
+func (step *Step) less(other *Step) bool {
+	if step.IsComposite() != other.IsComposite() {
+		return !step.IsComposite()
+	}
+	if step.IsComposite() {
+		return step.key() < other.key()
+	}
+	return step.RingGroup < other.RingGroup
 }
 
 // Validate 合法化
@@ -39,8 +67,8 @@ func (steps Steps) Standardize() Steps {
 	sorted := make(Steps, len(steps))
 	copy(sorted, steps)
 	// 排序
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].RingGroup < sorted[j].RingGroup
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].less(&sorted[j])
 	})
 	// 合并
 	var simplified Steps
@@ -48,7 +76,7 @@ func (steps Steps) Standardize() Steps {
 		if s.Count <= 0 {
 			continue
 		}
-		if len(simplified) > 0 && simplified[len(simplified)-1].RingGroup == s.RingGroup {
+		if len(simplified) > 0 && simplified[len(simplified)-1].key() == s.key() {
 			simplified[len(simplified)-1].Count += s.Count
 		} else {
 			simplified = append(simplified, s)
@@ -57,6 +85,80 @@ func (steps Steps) Standardize() Steps {
 	return simplified
 }
 
+// compact 保持顺序，去掉转动次数为 0 的步骤并合并相邻的相同步骤
+func (steps Steps) compact() Steps {
+	var ret Steps
+	for _, s := range steps {
+		if s.Count <= 0 {
+			continue
+		}
+		if len(ret) > 0 && ret[len(ret)-1].key() == s.key() {
+			ret[len(ret)-1].Count += s.Count
+		} else {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// TotalCount 返回总转动次数
+func (steps Steps) TotalCount() int {
+	total := 0
+	for _, s := range steps {
+		if s.Count > 0 {
+			total += s.Count
+		}
+	}
+	return total
+}
+
+// GroupCounts 返回各圈分组的总转动次数
+// 转动复合圈分组时，其中每个圈分组都计一次转动
+func (steps Steps) GroupCounts() map[RingGroup]int {
+	ret := map[RingGroup]int{}
+	for _, s := range steps {
+		if s.Count <= 0 {
+			continue
+		}
+		if !s.IsComposite() {
+			ret[s.RingGroup] += s.Count
+			continue
+		}
+		for _, rg := range s.Composite {
+			ret[rg] += s.Count
+		}
+	}
+	return ret
+}
+
+// MaxCount 返回标准化后单个圈分组的最大转动次数
+func (steps Steps) MaxCount() int {
+	max := 0
+	for _, s := range steps.Standardize() {
+		if s.Count > max {
+			max = s.Count
+		}
+	}
+	return max
+}
+
+// TotalCost 返回按 groupCost 计算的总代价，未指定代价的圈分组转动一次的代价为 1
+// 转动复合圈分组一次的代价为其中各圈分组代价的最大值，与 NewMinCostSolver 一致
+func (steps Steps) TotalCost(groupCost map[RingGroup]int) int {
+	total := 0
+	for i := range steps {
+		if steps[i].Count > 0 {
+			total += steps[i].Count * stepCost(groupCost, &steps[i])
+		}
+	}
+	return total
+}
+
+// Dials 返回标准化后转动的圈分组（及复合圈分组）数，即需要操作的不同转盘数
+func (steps Steps) Dials() int {
+	return len(steps.Standardize())
+}
+
 // String 转为字符串表示
 func (steps Steps) String() string {
 	// 标准化
@@ -73,6 +175,17 @@ func (steps Steps) String() string {
 	return strings.Join(stepStrs, ",")
 }
 
+// OrderedString 按转动顺序转为字符串表示，只合并相邻的相同步骤，比如 "o1,om2,o1"
+// 与 String 不同，不会标准化，用于转动顺序会影响结果的解法，参见 SolverOptions.Avoid
+func (steps Steps) OrderedString() string {
+	compacted := steps.compact()
+	stepStrs := make([]string, len(compacted))
+	for i := range compacted {
+		stepStrs[i] = compacted[i].String()
+	}
+	return strings.Join(stepStrs, ",")
+}
+
 // Validate TODO 合法化
 func (steps Steps) Validate() error {
 	return nil
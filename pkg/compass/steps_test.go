@@ -20,3 +20,49 @@ func TestStepsString(t *testing.T) {
 		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
 	}
 }
+
+// TestStepsDials 测试 Steps.Dials 方法
+func TestStepsDials(t *testing.T) {
+	steps := Steps{
+		{RingGroup: InnerRingGroup, Count: 1},
+		{RingGroup: OuterMiddleRingGroup, Count: 3},
+		{RingGroup: InnerRingGroup, Count: 2},
+		{RingGroup: MiddleRingGroup, Count: 0},
+		{Composite: []RingGroup{OuterMiddleRingGroup, InnerRingGroup}, Count: 1},
+	}
+	if ret := steps.Dials(); ret != 3 {
+		t.Errorf("unexpected result: %d (expected: %d)", ret, 3)
+	}
+}
+
+// TestStepsTotalCost 测试 Steps.TotalCost 方法
+func TestStepsTotalCost(t *testing.T) {
+	steps := Steps{
+		{RingGroup: InnerRingGroup, Count: 2},
+		{RingGroup: OuterMiddleRingGroup, Count: 3},
+		{Composite: []RingGroup{OuterMiddleRingGroup, InnerRingGroup}, Count: 1},
+	}
+	groupCost := map[RingGroup]int{InnerRingGroup: 4}
+	// 2*4 + 3*1 + 1*max(1, 4)
+	if ret := steps.TotalCost(groupCost); ret != 15 {
+		t.Errorf("unexpected result: %d (expected: %d)", ret, 15)
+	}
+	if ret := steps.TotalCost(nil); ret != steps.TotalCount() {
+		t.Errorf("unexpected result: %d (expected: %d)", ret, steps.TotalCount())
+	}
+}
+
+// TestStepsOrderedString 测试 Steps.OrderedString 方法
+func TestStepsOrderedString(t *testing.T) {
+	steps := Steps{
+		{RingGroup: OuterRingGroup, Count: 1},
+		{RingGroup: OuterMiddleRingGroup, Count: 1},
+		{RingGroup: MiddleRingGroup, Count: 0},
+		{RingGroup: OuterMiddleRingGroup, Count: 1},
+		{RingGroup: OuterRingGroup, Count: 1},
+	}
+	expectedRet := "o1,om2,o1"
+	if ret := steps.OrderedString(); ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+}
@@ -0,0 +1,37 @@
+package compass
+
+// SymmetryOrder 返回罗盘转动结构在整体旋转下的对称群的阶
+// 整体旋转即三个圈同时沿顺时针方向转动相同的格数 k （ 0-5 ）。每次转动的位移与当前状态无关，
+// 所以整体旋转总是把状态转移图映射为自身；其中把能转到目标状态的状态仍映射为能转到目标状态的状态的
+// （即目标状态整体旋转 k 格后仍能转回目标状态）构成对称群，阶是 1 、 2 、 3 或 6 。
+// 比如各圈的旋转速度都为 +1 且都可以单独转动时为 6 ，都为 +2 时为 3 ，只能转动一个圈时为 1 。
+// 对称群中的整体旋转不改变罗盘能否解开。 compass 为 nil 时返回 0
+func (compass *Compass) SymmetryOrder() int {
+	if compass == nil {
+		return 0
+	}
+	// 从目标状态能到达的状态
+	moves := compass.moves()
+	reached := [216]bool{targetHash: true}
+	queue := []int{targetHash}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for i := range moves {
+			next := rotateHashStep(compass, cur, &moves[i])
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	order := 0
+	for k := 0; k < 6; k++ {
+		loc := (TargetLocation + k) % 6
+		if reached[loc*36+loc*6+loc] {
+			order++
+		}
+	}
+	return order
+}
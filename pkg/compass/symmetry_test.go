@@ -0,0 +1,52 @@
+package compass
+
+import (
+	"testing"
+)
+
+// TestCompassSymmetryOrder 测试 Compass.SymmetryOrder 方法
+func TestCompassSymmetryOrder(t *testing.T) {
+	cases := []struct {
+		compass       string
+		expectedOrder int
+	}{
+		// 各圈的旋转速度相同，三个圈都可以单独转动，对称的整体旋转为旋转速度的倍数
+		{compass: "0+1,0+1,0+1/o,m,i", expectedOrder: 6},
+		{compass: "0+2,0+2,0+2/o,m,i", expectedOrder: 3},
+		{compass: "0+3,0+3,0+3/o,m,i", expectedOrder: 2},
+		// -4 与 +2 等效
+		{compass: "0-4,0+2,0+2/o,m,i", expectedOrder: 3},
+		// 复合圈分组的位移也参与组合，但不能组合出整体旋转 1 格
+		{compass: "0+1,0+1,0+1/om,mi,(o+i)", expectedOrder: 3},
+		{compass: "0+1,4-4,0+2/oi,om,mi", expectedOrder: 3},
+		// 内圈只能转动偶数格
+		{compass: "0+1,0+1,0+2/om,i", expectedOrder: 3},
+		// 不能同时转动三个圈，只有不旋转是对称
+		{compass: "0+1,0+1,0+1/o", expectedOrder: 1},
+		{compass: "0+1,0+1,0+1/om", expectedOrder: 1},
+		{compass: "0+1,4-4,0+3/oi,om,mi", expectedOrder: 1},
+	}
+	for _, tc := range cases {
+		c, err := ParseCompass(tc.compass)
+		if err != nil {
+			t.Fatalf("parse compass %s error: %s", tc.compass, err)
+		}
+		if ret := c.SymmetryOrder(); ret != tc.expectedOrder {
+			t.Errorf("unexpected result of %s: %d (expected: %d)", tc.compass, ret, tc.expectedOrder)
+		}
+	}
+
+	// 指定了位移的圈分组一次转动三个圈
+	c := &Compass{
+		RingGroups:  []RingGroup{OuterMiddleRingGroup},
+		GroupEffect: map[RingGroup][3]int{OuterMiddleRingGroup: {1, 1, 1}},
+	}
+	if ret := c.SymmetryOrder(); ret != 6 {
+		t.Errorf("unexpected result of %s: %d (expected: 6)", c, ret)
+	}
+
+	var nilCompass *Compass
+	if ret := nilCompass.SymmetryOrder(); ret != 0 {
+		t.Errorf("unexpected result of nil compass: %d (expected: 0)", ret)
+	}
+}
@@ -0,0 +1,43 @@
+package compass
+
+import (
+	"encoding"
+	"fmt"
+)
+
+var (
+	_ encoding.TextMarshaler   = &Compass{}
+	_ encoding.TextUnmarshaler = &Compass{}
+)
+
+// MarshalText 转为文本表示，即字符串表示
+// 字符串表示不包含 GroupEffect ，因此 GroupEffect 非空时转为 JSON 表示，以便 UnmarshalText 还原
+func (compass *Compass) MarshalText() ([]byte, error) {
+	if compass == nil {
+		return nil, fmt.Errorf("nil compass")
+	}
+	if len(compass.GroupEffect) > 0 {
+		return compass.MarshalJSON()
+	}
+	return []byte(compass.String()), nil
+}
+
+// UnmarshalText 从文本表示解析，支持 ParseCompass 支持的所有格式
+func (compass *Compass) UnmarshalText(text []byte) error {
+	ret, err := ParseCompass(string(text))
+	if err != nil {
+		return err
+	}
+	*compass = ret
+	return nil
+}
+
+// Set 从字符串表示解析，与 String 、 Type 一起实现 pflag.Value 接口，以便作为命令行参数的值
+func (compass *Compass) Set(s string) error {
+	return compass.UnmarshalText([]byte(s))
+}
+
+// Type 返回作为命令行参数的值时的类型名
+func (compass *Compass) Type() string {
+	return "compass"
+}
@@ -0,0 +1,61 @@
+package compass
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// TestCompassText 测试 Compass.MarshalText 及 Compass.UnmarshalText 方法
+func TestCompassText(t *testing.T) {
+	for _, expr := range []string{
+		"0+1,4-4,0+2/mi,oi,om",
+		"2-1,5+2,4+1/mi,o,(mi+o)",
+		// 不会转动的圈
+		"3+1,0-2,0+0/mi,o",
+		`{"outer":{"location":0,"speed":1},"middle":{"location":4,"speed":-4},"inner":{"location":0,"speed":2},"groups":["om"],"effects":{"om":[1,2,0]}}`,
+	} {
+		c, err := ParseCompass(expr)
+		if err != nil {
+			t.Fatalf("parse compass %s error: %s", expr, err)
+		}
+		text, err := c.MarshalText()
+		if err != nil {
+			t.Errorf("marshal %s error: %s", expr, err)
+			continue
+		}
+		if string(text) != expr {
+			t.Errorf("unexpected result: %s (expected: %s)", text, expr)
+		}
+		var ret Compass
+		if err := ret.UnmarshalText(text); err != nil {
+			t.Errorf("unmarshal %s error: %s", text, err)
+			continue
+		}
+		if !ret.Equal(&c) {
+			t.Errorf("unexpected result: %s (expected: %s)", ret.String(), c.String())
+		}
+	}
+
+	var c Compass
+	if err := c.UnmarshalText([]byte("0+1,4-4/o")); !errors.Is(err, ErrParseFormat) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrParseFormat)
+	}
+}
+
+// TestCompassFlagValue 测试 Compass 作为 pflag.Value 使用
+func TestCompassFlagValue(t *testing.T) {
+	var c Compass
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.Var(&c, "compass", "the compass")
+	if err := fs.Parse([]string{"--compass", "0+1,4-4,0+2/oi,om,mi"}); err != nil {
+		t.Fatalf("parse flags error: %s", err)
+	}
+	if expected := "0+1,4-4,0+2/mi,oi,om"; c.String() != expected {
+		t.Errorf("unexpected result: %s (expected: %s)", c.String(), expected)
+	}
+	if ret := fs.Lookup("compass").Value.Type(); ret != "compass" {
+		t.Errorf("unexpected type: %s (expected: %s)", ret, "compass")
+	}
+}
@@ -0,0 +1,81 @@
+package compass
+
+import (
+	"fmt"
+	"io"
+)
+
+// searchTracer 以 Graphviz DOT 格式记录求解器的搜索过程
+type searchTracer struct {
+	w   io.Writer
+	err error
+	// 已输出的状态
+	nodes map[int]bool
+	// 已输出的状态转移
+	edges map[traceEdge]bool
+}
+
+// traceEdge 状态转移
+type traceEdge struct {
+	from  int
+	label string
+	to    int
+}
+
+// newSearchTracer 创建一个搜索过程记录器
+func newSearchTracer(w io.Writer) *searchTracer {
+	t := &searchTracer{
+		w:     w,
+		nodes: map[int]bool{},
+		edges: map[traceEdge]bool{},
+	}
+	t.printf("digraph compass {\n")
+	return t
+}
+
+// traceSolution 记录尝试一个解法时经过的所有状态及状态转移
+func (t *searchTracer) traceSolution(compass Compass, solution Steps) {
+	cur := compass.Hash()
+	t.node(cur)
+	for _, s := range solution {
+		for i := 0; i < s.Count; i++ {
+			next := rotateHashStep(&compass, cur, &s)
+			t.node(next)
+			t.edge(traceEdge{from: cur, label: s.key(), to: next})
+			cur = next
+		}
+	}
+}
+
+// node 输出一个状态，已输出过的状态会被忽略
+func (t *searchTracer) node(hash int) {
+	if t.nodes[hash] {
+		return
+	}
+	t.nodes[hash] = true
+	// 标签为外圈、中圈、内圈位置
+	t.printf("  %d [label=\"%d,%d,%d\"];\n", hash, hash/36, hash/6%6, hash%6)
+}
+
+// edge 输出一个状态转移，已输出过的状态转移会被忽略
+func (t *searchTracer) edge(e traceEdge) {
+	if t.edges[e] {
+		return
+	}
+	t.edges[e] = true
+	t.printf("  %d -> %d [label=\"%s\"];\n", e.from, e.to, e.label)
+}
+
+// close 结束记录，返回记录过程中遇到的第一个写入错误
+func (t *searchTracer) close() error {
+	t.printf("}\n")
+	return t.err
+}
+
+// printf 格式化写入，遇到错误后不再写入
+func (t *searchTracer) printf(format string, a ...interface{}) {
+	if t.err != nil {
+		return
+	}
+	_, t.err = fmt.Fprintf(t.w, format, a...)
+}
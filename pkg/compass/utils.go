@@ -20,7 +20,16 @@ func CheckSolution(compass Compass, solution Steps) (bool, error) {
 
 	// 转一下
 	for _, s := range solution {
-		if !compass.IsRingGroupSupported(s.RingGroup) {
+		rgs := []RingGroup{s.RingGroup}
+		if s.IsComposite() {
+			if !compass.IsCompositeGroupSupported(s.Composite) {
+				return false, fmt.Errorf(
+					"steps contains composite group not supported by compass: %s",
+					"("+compositeKey(s.Composite)+")",
+				)
+			}
+			rgs = s.Composite
+		} else if !compass.IsRingGroupSupported(s.RingGroup) {
 			return false, fmt.Errorf(
 				"steps contains ring group not supported by compass: %s (must be one of %v)",
 				s.RingGroup.Name(),
@@ -28,20 +37,48 @@ func CheckSolution(compass Compass, solution Steps) (bool, error) {
 			)
 		}
 
-		if s.RingGroup&OuterRingGroup > 0 {
-			outer += s.Count * compass.OuterRing.Speed
-		}
-		if s.RingGroup&MiddleRingGroup > 0 {
-			middle += s.Count * compass.MiddleRing.Speed
-		}
-		if s.RingGroup&InnerRingGroup > 0 {
-			inner += s.Count * compass.InnerRing.Speed
+		for _, rg := range rgs {
+			effect := compass.groupEffect(rg)
+			outer += s.Count * effect[0]
+			middle += s.Count * effect[1]
+			inner += s.Count * effect[2]
 		}
 	}
 
 	// 检查各圈最终位置
-	if inner%6 != 0 || middle%6 != 0 || outer%6 != 0 {
+	if (inner-TargetLocation)%6 != 0 || (middle-TargetLocation)%6 != 0 || (outer-TargetLocation)%6 != 0 {
 		return false, nil
 	}
 	return true, nil
 }
+
+// rotateHash 返回 hash 表示的状态按圈分组转动一次后的状态
+// 使用 compass 中各圈的旋转速度（或 GroupEffect 中指定的位移），参见 Compass.Hash
+func rotateHash(compass *Compass, hash int, rg RingGroup) int {
+	effect := compass.groupEffect(rg)
+	outer := ((hash/36+effect[0])%6 + 6) % 6
+	middle := ((hash/6%6+effect[1])%6 + 6) % 6
+	inner := ((hash%6+effect[2])%6 + 6) % 6
+	return outer*36 + middle*6 + inner
+}
+
+// compassAtHash 返回各圈位置为 hash 表示的状态的罗盘，其余与 compass 相同
+func compassAtHash(compass *Compass, hash int) *Compass {
+	ret := compass.Clone()
+	ret.OuterRing.Location = hash / 36
+	ret.MiddleRing.Location = hash / 6 % 6
+	ret.InnerRing.Location = hash % 6
+	return ret
+}
+
+// rotateHashStep 返回 hash 表示的状态按步骤转动一次后的状态，忽略步骤的转动次数
+// 步骤转动的是复合圈分组时，依次转动其中各圈分组
+func rotateHashStep(compass *Compass, hash int, step *Step) int {
+	if !step.IsComposite() {
+		return rotateHash(compass, hash, step.RingGroup)
+	}
+	for _, rg := range step.Composite {
+		hash = rotateHash(compass, hash, rg)
+	}
+	return hash
+}
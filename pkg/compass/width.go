@@ -0,0 +1,67 @@
+package compass
+
+import (
+	"strings"
+	"unicode"
+)
+
+// wideRanges 东亚宽字符（在等宽终端中占两列）以及常见 emoji 的码点范围
+var wideRanges = []struct {
+	lo, hi rune
+}{
+	{0x1100, 0x115F},   // 谚文字母
+	{0x2E80, 0x303E},   // 中日韩部首、符号和标点
+	{0x3041, 0x33FF},   // 假名、注音、中日韩兼容字符
+	{0x3400, 0x4DBF},   // 中日韩统一表意文字扩展 A
+	{0x4E00, 0x9FFF},   // 中日韩统一表意文字
+	{0xA000, 0xA4CF},   // 彝文
+	{0xAC00, 0xD7A3},   // 谚文音节
+	{0xF900, 0xFAFF},   // 中日韩兼容表意文字
+	{0xFE30, 0xFE4F},   // 中日韩兼容形式
+	{0xFF00, 0xFF60},   // 全角字符
+	{0xFFE0, 0xFFE6},   // 全角符号
+	{0x1F300, 0x1F64F}, // 符号、象形文字及表情
+	{0x1F900, 0x1F9FF}, // 补充符号及象形文字
+	{0x20000, 0x3FFFD}, // 中日韩统一表意文字扩展 B 及之后
+}
+
+// runeWidth 返回字符在等宽终端中占用的列数
+// 组合字符及格式字符（比如 emoji 变体选择符 U+FE0F ）不占列，宽字符占两列，其余占一列
+func runeWidth(r rune) int {
+	if unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+	for _, wr := range wideRanges {
+		if r >= wr.lo && r <= wr.hi {
+			return 2
+		}
+	}
+	return 1
+}
+
+// emojiPresentation emoji 变体选择符，使前一个字符以 emoji 样式展示，比如 "❤️" 为 U+2764 U+FE0F
+const emojiPresentation = '\uFE0F'
+
+// displayWidth 返回字符串在等宽终端中占用的列数
+// 后接 emoji 变体选择符的字符以 emoji 样式展示，占两列
+func displayWidth(s string) int {
+	w, last := 0, 0
+	for _, r := range s {
+		if r == emojiPresentation && last == 1 {
+			w++
+			last = 2
+			continue
+		}
+		last = runeWidth(r)
+		w += last
+	}
+	return w
+}
+
+// padRight 在字符串右侧填充空格，使其在等宽终端中至少占用 width 列
+func padRight(s string, width int) string {
+	if w := displayWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
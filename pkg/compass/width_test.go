@@ -0,0 +1,45 @@
+package compass
+
+import (
+	"testing"
+)
+
+// TestDisplayWidth 测试 displayWidth 函数
+func TestDisplayWidth(t *testing.T) {
+	for s, expected := range map[string]int{
+		"":      0,
+		"outer": 5,
+		"外圈":    4,
+		"外圈 o":  6,
+		"ｏｕｔｅｒ": 10,
+		"바깥":    4,
+		"⬅️":    2,
+		"❤️":    2,
+		"❤":     1,
+		"a❤️b":  4,
+		"🔴":     2,
+		"é":    1,
+	} {
+		if ret := displayWidth(s); ret != expected {
+			t.Errorf("unexpected width of %#v: %d (expected: %d)", s, ret, expected)
+		}
+	}
+}
+
+// TestPadRight 测试 padRight 函数
+func TestPadRight(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{s: "outer", width: 6, expected: "outer "},
+		{s: "外圈", width: 6, expected: "外圈  "},
+		{s: "中间圈", width: 6, expected: "中间圈"},
+		{s: "内圈内圈", width: 6, expected: "内圈内圈"},
+	} {
+		if ret := padRight(c.s, c.width); ret != c.expected {
+			t.Errorf("unexpected result: %#v (expected: %#v)", ret, c.expected)
+		}
+	}
+}
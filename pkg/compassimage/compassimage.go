@@ -0,0 +1,131 @@
+// Package compassimage 将引航罗盘绘制为图片
+package compassimage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// MinSize 图片的最小边长（像素）
+const MinSize = 32
+
+var (
+	// 背景颜色
+	backgroundColor = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	// 圈的颜色
+	trackColor = color.RGBA{R: 0xc8, G: 0xc8, B: 0xc8, A: 0xff}
+	// 目标位置标记的颜色
+	targetColor = color.RGBA{R: 0xe0, G: 0xa0, B: 0x00, A: 0xff}
+
+	// OuterColor 外圈指针的颜色
+	OuterColor = color.RGBA{R: 0xd0, G: 0x30, B: 0x30, A: 0xff}
+	// MiddleColor 中圈指针的颜色
+	MiddleColor = color.RGBA{R: 0x30, G: 0xa0, B: 0x30, A: 0xff}
+	// InnerColor 内圈指针的颜色
+	InnerColor = color.RGBA{R: 0x30, G: 0x50, B: 0xd0, A: 0xff}
+)
+
+// imageRing 绘制时的一个圈
+type imageRing struct {
+	// 半径，相对于图片边长
+	radius float64
+	color  color.RGBA
+	ring   compass.Ring
+}
+
+// Draw 将罗盘绘制为边长为 size 像素的正方形图片
+// 三个同心圈从外到内分别为外圈、中圈、内圈，指针以圈上的圆点表示，颜色分别为 OuterColor 、 MiddleColor 、 InnerColor ，
+// 目标位置（正左方）在外圈外侧以三角形标记，位置 0 为正左方，沿顺时针方向每个位置 60 度
+func Draw(c *compass.Compass, size int) (*image.RGBA, error) {
+	if c == nil {
+		return nil, fmt.Errorf("%w: compass is nil", compass.ErrInvalidCompass)
+	}
+	if size < MinSize {
+		return nil, fmt.Errorf("invalid image size: %d (must be at least %d)", size, MinSize)
+	}
+	std := c.Standardize()
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	fill(img, func(_, _ float64) bool { return true }, backgroundColor)
+
+	s := float64(size)
+	center := s / 2
+	rings := []imageRing{
+		{radius: 0.40, color: OuterColor, ring: std.OuterRing},
+		{radius: 0.28, color: MiddleColor, ring: std.MiddleRing},
+		{radius: 0.16, color: InnerColor, ring: std.InnerRing},
+	}
+	thickness := s / 100
+	if thickness < 1 {
+		thickness = 1
+	}
+
+	// 圈
+	for _, r := range rings {
+		radius := r.radius * s
+		fill(img, func(x, y float64) bool {
+			d := math.Hypot(x-center, y-center)
+			return math.Abs(d-radius) <= thickness/2
+		}, trackColor)
+	}
+
+	// 目标位置标记，尖端指向罗盘中心
+	tipX := center - 0.44*s
+	baseX := center - 0.49*s
+	halfHeight := 0.04 * s
+	fill(img, func(x, y float64) bool {
+		if x < baseX || x > tipX {
+			return false
+		}
+		return math.Abs(y-center) <= halfHeight*(tipX-x)/(tipX-baseX)
+	}, targetColor)
+
+	// 指针
+	for _, r := range rings {
+		px, py := PointerPosition(r.ring.Location, r.radius*s)
+		px, py = px+center, py+center
+		dotRadius := 0.035 * s
+		fill(img, func(x, y float64) bool {
+			return math.Hypot(x-px, y-py) <= dotRadius
+		}, r.color)
+	}
+
+	return img, nil
+}
+
+// Encode 将罗盘绘制为边长为 size 像素的 PNG 图片并写入 w
+func Encode(w io.Writer, c *compass.Compass, size int) error {
+	img, err := Draw(c, size)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("encode png error: %w", err)
+	}
+	return nil
+}
+
+// PointerPosition 返回位于 location 的指针相对于罗盘中心的坐标（图片坐标系， y 轴向下）
+// radius 为指针所在圈的半径
+func PointerPosition(location int, radius float64) (x, y float64) {
+	// 位置 0 为正左方，沿顺时针方向每个位置 60 度
+	angle := math.Pi + float64(location)*math.Pi/3
+	return radius * math.Cos(angle), radius * math.Sin(angle)
+}
+
+// fill 将图片中像素中心满足 inside 的像素填充为指定颜色
+func fill(img *image.RGBA, inside func(x, y float64) bool, c color.RGBA) {
+	bounds := img.Bounds()
+	for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+		for px := bounds.Min.X; px < bounds.Max.X; px++ {
+			if inside(float64(px)+0.5, float64(py)+0.5) {
+				img.SetRGBA(px, py, c)
+			}
+		}
+	}
+}
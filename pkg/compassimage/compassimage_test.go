@@ -0,0 +1,92 @@
+package compassimage
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestDraw 测试 Draw
+func TestDraw(t *testing.T) {
+	c := &compass.Compass{
+		OuterRing:  compass.Ring{Location: 0, Speed: 1},
+		MiddleRing: compass.Ring{Location: 2, Speed: -4},
+		InnerRing:  compass.Ring{Location: 3, Speed: 2},
+		RingGroups: []compass.RingGroup{compass.OuterMiddleRingGroup, compass.InnerRingGroup},
+	}
+	const size = 200
+	img, err := Draw(c, size)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if img.Bounds().Dx() != size || img.Bounds().Dy() != size {
+		t.Errorf("unexpected size: %v (expected: %dx%d)", img.Bounds(), size, size)
+	}
+
+	// 各圈指针位置的像素应为对应的颜色
+	for _, tc := range []struct {
+		location int
+		radius   float64
+		color    color.RGBA
+	}{
+		{location: 0, radius: 0.40, color: OuterColor},
+		{location: 2, radius: 0.28, color: MiddleColor},
+		{location: 3, radius: 0.16, color: InnerColor},
+	} {
+		x, y := PointerPosition(tc.location, tc.radius*size)
+		px, py := int(x+size/2), int(y+size/2)
+		if got := img.RGBAAt(px, py); got != tc.color {
+			t.Errorf("unexpected color at location %d: %#v (expected: %#v)", tc.location, got, tc.color)
+		}
+	}
+
+	// 非法参数
+	if _, err := Draw(c, MinSize-1); err == nil {
+		t.Errorf("expected error drawing with size %d", MinSize-1)
+	}
+	if _, err := Draw(nil, size); err == nil {
+		t.Errorf("expected error drawing nil compass")
+	}
+}
+
+// TestPointerPosition 测试 PointerPosition
+func TestPointerPosition(t *testing.T) {
+	for _, tc := range []struct {
+		location int
+		// 期望的方向
+		left, up bool
+	}{
+		{location: 0, left: true},
+		{location: 1, left: true, up: true},
+		{location: 2, up: true},
+		{location: 4},
+		{location: 5, left: true},
+	} {
+		x, y := PointerPosition(tc.location, 10)
+		if (x < 0) != tc.left || (y < -1e-9) != tc.up {
+			t.Errorf("unexpected position of location %d: (%.2f, %.2f)", tc.location, x, y)
+		}
+	}
+}
+
+// TestEncode 测试 Encode
+func TestEncode(t *testing.T) {
+	c := &compass.Compass{
+		OuterRing:  compass.Ring{Location: 1, Speed: 1},
+		RingGroups: []compass.RingGroup{compass.OuterRingGroup},
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, c, 64); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode png error: %s", err)
+	}
+	if img.Bounds().Dx() != 64 {
+		t.Errorf("unexpected width: %d (expected: 64)", img.Bounds().Dx())
+	}
+}
@@ -0,0 +1,61 @@
+package compassimage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// palette GIF 帧使用的调色板，包含绘制罗盘用到的所有颜色
+var palette = color.Palette{backgroundColor, trackColor, targetColor, OuterColor, MiddleColor, InnerColor}
+
+// EncodeGIF 将按步骤逐次转动罗盘的过程绘制为边长为 size 像素的 GIF 动画并写入 w
+// 第一帧为初始状态，之后每转动一次一帧，最后一帧即按步骤转动后的状态（步骤为解法时即目标状态）。
+// 按步骤的顺序转动，每帧显示 delay 个百分之一秒，动画循环播放
+func EncodeGIF(w io.Writer, c *compass.Compass, steps compass.Steps, size int, delay int) error {
+	if c == nil {
+		return fmt.Errorf("%w: compass is nil", compass.ErrInvalidCompass)
+	}
+	if delay < 0 {
+		return fmt.Errorf("invalid frame delay: %d (must not be negative)", delay)
+	}
+	anim := &gif.GIF{}
+	addFrame := func(state *compass.Compass) error {
+		img, err := Draw(state, size)
+		if err != nil {
+			return err
+		}
+		frame := image.NewPaletted(img.Bounds(), palette)
+		draw.Draw(frame, frame.Bounds(), img, image.Point{}, draw.Src)
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, delay)
+		return nil
+	}
+
+	cur := c.Clone()
+	if err := addFrame(cur); err != nil {
+		return err
+	}
+	for _, step := range steps {
+		click := step
+		click.Count = 1
+		for i := 0; i < step.Count; i++ {
+			if err := cur.ApplySteps(compass.Steps{click}); err != nil {
+				return fmt.Errorf("apply step %s error: %w", step.String(), err)
+			}
+			if err := addFrame(cur); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := gif.EncodeAll(w, anim); err != nil {
+		return fmt.Errorf("encode gif error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package compassimage
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestEncodeGIF 测试 EncodeGIF
+func TestEncodeGIF(t *testing.T) {
+	c, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	solution, err := compass.ParseSteps("mi2,oi4,om2")
+	if err != nil {
+		t.Fatalf("parse steps error: %s", err)
+	}
+	var buf bytes.Buffer
+	if err := EncodeGIF(&buf, &c, solution, 64, 50); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("decode gif error: %s", err)
+	}
+	// 初始状态及每次转动各一帧
+	if len(anim.Image) != 1+solution.TotalCount() {
+		t.Fatalf("unexpected frames: %d (expected: %d)", len(anim.Image), 1+solution.TotalCount())
+	}
+	if anim.Delay[0] != 50 {
+		t.Errorf("unexpected delay: %d (expected: 50)", anim.Delay[0])
+	}
+
+	// 最后一帧为目标状态
+	solved := c.Clone()
+	solved.OuterRing.Location, solved.MiddleRing.Location, solved.InnerRing.Location = 0, 0, 0
+	expected, err := Draw(solved, 64)
+	if err != nil {
+		t.Fatalf("draw error: %s", err)
+	}
+	last := anim.Image[len(anim.Image)-1]
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if color.RGBAModel.Convert(last.At(x, y)) != expected.At(x, y) {
+				t.Fatalf("unexpected color of the last frame at (%d, %d): %v (expected: %v)", x, y, last.At(x, y), expected.At(x, y))
+			}
+		}
+	}
+
+	if err := EncodeGIF(&buf, &c, solution, 64, -1); err == nil {
+		t.Errorf("expected error with negative delay")
+	}
+}
@@ -0,0 +1,161 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// 用户配置目录下保存配置的文件
+const defaultFileName = "hksr-compass/profiles.json"
+
+// ErrNotFound 没有指定名称的配置
+var ErrNotFound = errors.New("profile not found")
+
+// Builtin 内置的配置，名称到配置表达式，参见 Parse
+// 只有一个示例配置，即 README 中的示例罗盘，不对应游戏中某处具体的谜题。
+// 游戏中各处谜题的旋转速度及圈分组无法逐一核实，暂不内置，核实后再添加；实际使用的配置请添加到配置文件中
+var Builtin = map[string]string{
+	"example": "+1,-4,+2/mi,oi,om",
+}
+
+// Profiles 具名的罗盘配置，即各圈的旋转速度及支持的圈分组
+// 同一处谜题的罗盘通常只有各圈位置不同，使用配置时只需给出各圈位置。
+// 配置来自内置配置及 JSON 文件（名称到配置表达式的对象），文件中的配置覆盖同名的内置配置
+type Profiles struct {
+	path string
+}
+
+// New 创建一个从指定文件读取的 Profiles ，文件不存在时只有内置配置
+func New(path string) *Profiles {
+	return &Profiles{path: path}
+}
+
+// Default 返回从用户配置目录下（比如 Linux 下的 ~/.config/hksr-compass/profiles.json ）读取的 Profiles
+func Default() (*Profiles, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("get user config dir error: %w", err)
+	}
+	return New(filepath.Join(dir, defaultFileName)), nil
+}
+
+// Path 返回配置文件
+func (p *Profiles) Path() string {
+	return p.path
+}
+
+// All 返回所有配置，名称到各圈位置均为 0 的罗盘
+// 读取时校验每个配置（参见 compass.Compass.Validate ），任意一个配置不合法时返回错误
+func (p *Profiles) All() (map[string]compass.Compass, error) {
+	exprs := map[string]string{}
+	for name, expr := range Builtin {
+		exprs[name] = expr
+	}
+	data, err := os.ReadFile(p.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read profiles error: %w", err)
+	}
+	if err == nil {
+		var file map[string]string
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("parse profiles %s error: %w", p.path, err)
+		}
+		for name, expr := range file {
+			exprs[name] = expr
+		}
+	}
+
+	ret := make(map[string]compass.Compass, len(exprs))
+	for name, expr := range exprs {
+		c, err := Parse(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid profile %s: %w", name, err)
+		}
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid profile %s: %w", name, err)
+		}
+		ret[name] = c
+	}
+	return ret, nil
+}
+
+// Get 返回指定名称的配置，没有时返回包装了 ErrNotFound 的错误
+func (p *Profiles) Get(name string) (compass.Compass, error) {
+	all, err := p.All()
+	if err != nil {
+		return compass.Compass{}, err
+	}
+	c, ok := all[name]
+	if !ok {
+		names := make([]string, 0, len(all))
+		for n := range all {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return compass.Compass{}, fmt.Errorf("%w: \"%s\" (available: %v)", ErrNotFound, name, names)
+	}
+	return c, nil
+}
+
+// Expand 以指定名称的配置及各圈位置（依次为外圈、中圈、内圈，以 , 分隔，比如 "3,0,5" ）构造罗盘表达式
+// 以 ";" 分隔的多组位置分别构造，结果同样以 ";" 分隔，参见 compass.ParsePuzzle
+func (p *Profiles) Expand(name, locations string) (string, error) {
+	c, err := p.Get(name)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(locations, ";")
+	for i, part := range parts {
+		located, err := WithLocations(c, part)
+		if err != nil {
+			return "", err
+		}
+		parts[i] = located.String()
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// Parse 解析配置表达式，即省略各圈位置的罗盘表达式，比如 "+1,-4,+2/oi,om,mi"
+// 返回各圈位置均为 0 的罗盘，格式错误时返回包装了 compass.ErrParseFormat 的错误
+func Parse(expr string) (compass.Compass, error) {
+	slash := strings.Index(expr, "/")
+	if slash < 0 {
+		return compass.Compass{}, fmt.Errorf("%w: profile expression \"%s\" has no ring groups", compass.ErrParseFormat, expr)
+	}
+	speeds := strings.Split(expr[:slash], ",")
+	if len(speeds) != 3 {
+		return compass.Compass{}, fmt.Errorf("%w: profile expression \"%s\" must have 3 speeds", compass.ErrParseFormat, expr)
+	}
+	for i, speed := range speeds {
+		speeds[i] = "0" + strings.TrimSpace(speed)
+	}
+	return compass.ParseCompass(strings.Join(speeds, ",") + expr[slash:])
+}
+
+// WithLocations 返回各圈位置为 locations （依次为外圈、中圈、内圈，以 , 分隔，比如 "3,0,5" ）的罗盘，其余与 c 相同
+func WithLocations(c compass.Compass, locations string) (compass.Compass, error) {
+	parts := strings.Split(locations, ",")
+	if len(parts) != 3 {
+		return compass.Compass{}, fmt.Errorf("%w: locations \"%s\" must be 3 locations separated by \",\"", compass.ErrParseFormat, locations)
+	}
+	var locs [3]int
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if len(part) != 1 || part[0] < '0' || part[0] > '5' {
+			return compass.Compass{}, fmt.Errorf("%w: invalid location \"%s\" (must be in range 0-5)", compass.ErrParseFormat, part)
+		}
+		locs[i] = int(part[0] - '0')
+	}
+	ret := c.Clone()
+	ret.OuterRing.Location = locs[0]
+	ret.MiddleRing.Location = locs[1]
+	ret.InnerRing.Location = locs[2]
+	return *ret, nil
+}
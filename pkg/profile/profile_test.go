@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestProfiles 测试内置及文件中的配置
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	p := New(path)
+
+	// 文件不存在时只有内置配置
+	ret, err := p.Expand("example", "0,4,0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "0+1,4-4,0+2/mi,oi,om"; ret != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expected)
+	}
+
+	// 文件中的配置覆盖同名的内置配置
+	if err := os.WriteFile(path, []byte(`{"example": "+1,-2,+2/o,mi", "room1": "+1,-2,+1/o,mi,(o+mi)"}`), 0o644); err != nil {
+		t.Fatalf("write profiles error: %s", err)
+	}
+	if ret, err = p.Expand("example", "3,0,0; 1,1,1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "3+1,0-2,0+2/mi,o;1+1,1-2,1+2/mi,o"; ret != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expected)
+	}
+	if ret, err = p.Expand("room1", "0,1,2"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := "0+1,1-2,2+1/mi,o,(mi+o)"; ret != expected {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expected)
+	}
+
+	// 没有的配置
+	if _, err := p.Expand("foo", "0,0,0"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrNotFound)
+	}
+	// 位置不合法
+	for _, locations := range []string{"0,0", "0,0,6", "0,a,0"} {
+		if _, err := p.Expand("example", locations); !errors.Is(err, compass.ErrParseFormat) {
+			t.Errorf("unexpected error of %#v: %v (expected: %s)", locations, err, compass.ErrParseFormat)
+		}
+	}
+	// 文件中的配置不合法时报错
+	if err := os.WriteFile(path, []byte(`{"bad": "+1,-2/o"}`), 0o644); err != nil {
+		t.Fatalf("write profiles error: %s", err)
+	}
+	if _, err := p.Get("example"); !errors.Is(err, compass.ErrParseFormat) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, compass.ErrParseFormat)
+	}
+	// 能解析但不合法的配置同样报错，比如复合圈分组中的圈分组不在圈分组中
+	if err := os.WriteFile(path, []byte(`{"bad": "+1,-2,+1/(o+mi),i"}`), 0o644); err != nil {
+		t.Fatalf("write profiles error: %s", err)
+	}
+	if _, err := p.Get("example"); !errors.Is(err, compass.ErrInvalidCompass) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, compass.ErrInvalidCompass)
+	}
+}
+
+// TestBuiltin 测试内置配置都是合法的
+func TestBuiltin(t *testing.T) {
+	for name, expr := range Builtin {
+		c, err := Parse(expr)
+		if err != nil {
+			t.Errorf("unexpected error of profile %s: %s", name, err)
+			continue
+		}
+		if err := c.Validate(); err != nil {
+			t.Errorf("unexpected error of profile %s: %s", name, err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+const (
+	// 用户配置目录下保存罗盘的文件
+	defaultFileName = "hksr-compass/compasses.json"
+	// 引用已保存罗盘的前缀，比如 "@daily"
+	refPrefix = "@"
+)
+
+// ErrNotFound 没有指定名称的罗盘
+var ErrNotFound = errors.New("compass not found in store")
+
+// nameRegexp 罗盘名称
+var nameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Store 以 JSON 文件保存的具名罗盘
+// 文件内容是名称到罗盘 JSON 表示的对象，参见 compass.Compass.MarshalJSON
+type Store struct {
+	path string
+}
+
+// New 创建一个保存在指定文件中的 Store ，文件不存在时视为空，保存时自动创建
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Default 返回保存在用户配置目录下（比如 Linux 下的 ~/.config/hksr-compass/compasses.json ）的 Store
+func Default() (*Store, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("get user config dir error: %w", err)
+	}
+	return New(filepath.Join(dir, defaultFileName)), nil
+}
+
+// Path 返回保存罗盘的文件
+func (s *Store) Path() string {
+	return s.path
+}
+
+// All 返回所有已保存的罗盘
+func (s *Store) All() (map[string]compass.Compass, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]compass.Compass{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read store error: %w", err)
+	}
+	ret := map[string]compass.Compass{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("parse store %s error: %w", s.path, err)
+	}
+	return ret, nil
+}
+
+// Names 返回所有已保存的罗盘的名称（升序）
+func (s *Store) Names() ([]string, error) {
+	all, err := s.All()
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, 0, len(all))
+	for name := range all {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// Get 返回指定名称的罗盘，没有时返回包装了 ErrNotFound 的错误
+func (s *Store) Get(name string) (compass.Compass, error) {
+	all, err := s.All()
+	if err != nil {
+		return compass.Compass{}, err
+	}
+	c, ok := all[name]
+	if !ok {
+		names := make([]string, 0, len(all))
+		for n := range all {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return compass.Compass{}, fmt.Errorf("%w: \"%s\" (saved: %v)", ErrNotFound, name, names)
+	}
+	return c, nil
+}
+
+// Save 以指定名称保存罗盘，已有同名罗盘时覆盖
+// 先写入临时文件再重命名，避免写入中断时损坏已保存的罗盘
+func (s *Store) Save(name string, c compass.Compass) error {
+	if !nameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid name: \"%s\" (must match \"%s\")", name, nameRegexp.String())
+	}
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("compass validation error: %w", err)
+	}
+	all, err := s.All()
+	if err != nil {
+		return err
+	}
+	all[name] = c
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal store error: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create store dir error: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write store error: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("write store error: %w", err)
+	}
+	return nil
+}
+
+// Parser 返回解析罗盘表达式的函数，形如 "@NAME" 的表达式返回已保存的罗盘，其余使用 parse 解析
+// 已保存的罗盘不经过 parse ，因此不受表达式写法（比如 --notches ）的影响；可以与 compass.ParsePuzzleFunc 一起使用
+func (s *Store) Parser(parse func(string) (compass.Compass, error)) func(string) (compass.Compass, error) {
+	return func(expr string) (compass.Compass, error) {
+		if name := strings.TrimSpace(expr); strings.HasPrefix(name, refPrefix) {
+			return s.Get(strings.TrimPrefix(name, refPrefix))
+		}
+		return parse(expr)
+	}
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestStore 测试保存及读取罗盘
+func TestStore(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "sub", "compasses.json"))
+
+	// 文件不存在时视为空
+	names, err := s.Names()
+	if err != nil || len(names) != 0 {
+		t.Errorf("unexpected result of empty store: %v, %v (expected: [], nil)", names, err)
+	}
+
+	c, err := compass.ParseCompass("0+1,4-4,0+2/oi,om,mi")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	effect := c
+	effect.GroupEffect = map[compass.RingGroup][3]int{compass.OuterInnerRingGroup: {2, 0, 2}}
+	for name, v := range map[string]compass.Compass{"daily": c, "effect": effect} {
+		if err := s.Save(name, v); err != nil {
+			t.Fatalf("unexpected error saving %s: %s", name, err)
+		}
+	}
+	if err := s.Save("bad name", c); err == nil {
+		t.Errorf("expected error saving with invalid name")
+	}
+
+	names, err = s.Names()
+	if err != nil || !reflect.DeepEqual(names, []string{"daily", "effect"}) {
+		t.Errorf("unexpected names: %v, %v (expected: [daily effect], nil)", names, err)
+	}
+	got, err := s.Get("effect")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !got.Equal(&effect) || !reflect.DeepEqual(got.GroupEffect, effect.GroupEffect) {
+		t.Errorf("unexpected result: %s (expected: %s)", got.String(), effect.String())
+	}
+	if _, err := s.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrNotFound)
+	}
+}
+
+// TestStoreParser 测试 Store.Parser
+func TestStoreParser(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "compasses.json"))
+	c, err := compass.ParseCompass("3+1,0-2,0+2/o")
+	if err != nil {
+		t.Fatalf("parse compass error: %s", err)
+	}
+	if err := s.Save("room1", c); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cases := []struct {
+		expr        string
+		parse       func(string) (compass.Compass, error)
+		expectedRet string
+	}{
+		{expr: "0+1,4-4,0+2/oi,om,mi", parse: compass.ParseCompass, expectedRet: "0+1,4-4,0+2/mi,oi,om"},
+		{expr: "@room1", parse: compass.ParseCompass, expectedRet: "3+1,0-2,0+2/o"},
+		// 已保存的罗盘不受表达式写法的影响
+		{expr: "0cw1,4ccw4,0cw2/oi,om,mi; @room1", parse: compass.ParseCompassNotches, expectedRet: "0+1,4-4,0+2/mi,oi,om;3+1,0-2,0+2/o"},
+	}
+	for _, tc := range cases {
+		puzzle, err := compass.ParsePuzzleFunc(tc.expr, s.Parser(tc.parse))
+		if err != nil || puzzle.String() != tc.expectedRet {
+			t.Errorf("unexpected result of %#v: %#v, %v (expected: %#v)", tc.expr, puzzle.String(), err, tc.expectedRet)
+		}
+	}
+	if _, err := s.Parser(compass.ParseCompass)("@missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unexpected error: %v (expected: %s)", err, ErrNotFound)
+	}
+}
@@ -0,0 +1,88 @@
+package terms
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// defaultData 内置的叫法，即本项目文档中各圈分组的中文叫法
+//
+//go:embed default.json
+var defaultData []byte
+
+// Terms 展示解法时各圈分组的叫法，没有叫法的圈分组使用简写名
+type Terms map[compass.RingGroup]string
+
+// Default 返回内置的叫法
+func Default() Terms {
+	ret, err := Parse(defaultData)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default terms: %s", err))
+	}
+	return ret
+}
+
+// Load 从 JSON 文件读取叫法，参见 Parse
+func Load(path string) (Terms, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read terms error: %w", err)
+	}
+	ret, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse terms %s error: %w", path, err)
+	}
+	return ret, nil
+}
+
+// Parse 解析 JSON 格式的叫法，内容为圈分组（格式同 compass.ParseRingGroup ）到叫法的对象，比如 {"om": "外圈和中圈"}
+// 同一个圈分组的不同写法（比如 "om" 和 "mo" ）重复出现时返回错误
+func Parse(data []byte) (Terms, error) {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	ret := make(Terms, len(raw))
+	for key, term := range raw {
+		rg, err := compass.ParseRingGroup(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := ret[rg]; ok {
+			return nil, fmt.Errorf("duplicate term of ring group %s", rg.ShortName())
+		}
+		ret[rg] = term
+	}
+	return ret, nil
+}
+
+// Name 返回圈分组的叫法，没有叫法时返回简写名
+func (t Terms) Name(rg compass.RingGroup) string {
+	if term, ok := t[rg]; ok && term != "" {
+		return term
+	}
+	return rg.ShortName()
+}
+
+// FormatSolution 以各圈分组的叫法展示解法，比如 "中圈和内圈 ×2, 外圈和内圈 ×4, 外圈和中圈 ×2"
+// 按解法中步骤的顺序展示；复合圈分组展示为以 " + " 连接的各圈分组的叫法，并以括号包围
+func FormatSolution(solution compass.Steps, t Terms) string {
+	strs := make([]string, len(solution))
+	for i, step := range solution {
+		name := t.Name(step.RingGroup)
+		if step.IsComposite() {
+			names := make([]string, len(step.Composite))
+			for j, rg := range step.Composite {
+				names[j] = t.Name(rg)
+			}
+			name = "(" + strings.Join(names, " + ") + ")"
+		}
+		strs[i] = fmt.Sprintf("%s ×%d", name, step.Count)
+	}
+	return strings.Join(strs, ", ")
+}
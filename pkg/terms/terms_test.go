@@ -0,0 +1,52 @@
+package terms
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keybrl/hksr-compass/pkg/compass"
+)
+
+// TestDefault 测试内置的叫法包含所有圈分组
+func TestDefault(t *testing.T) {
+	terms := Default()
+	for _, rg := range []compass.RingGroup{
+		compass.OuterRingGroup, compass.MiddleRingGroup, compass.InnerRingGroup,
+		compass.OuterMiddleRingGroup, compass.OuterInnerRingGroup, compass.MiddleInnerRingGroup,
+	} {
+		if terms.Name(rg) == rg.ShortName() {
+			t.Errorf("missing default term of ring group %s", rg.ShortName())
+		}
+	}
+}
+
+// TestFormatSolution 测试 FormatSolution ，没有叫法的圈分组使用简写名
+func TestFormatSolution(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terms.json")
+	if err := os.WriteFile(path, []byte(`{"mo": "Top & Middle", "i": "Bottom"}`), 0o644); err != nil {
+		t.Fatalf("write terms error: %s", err)
+	}
+	terms, err := Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	solution, err := compass.ParseSteps("om2,oi4,(om+i)1")
+	if err != nil {
+		t.Fatalf("parse steps error: %s", err)
+	}
+	expectedRet := "Top & Middle ×2, oi ×4, (Top & Middle + Bottom) ×1"
+	if ret := FormatSolution(solution, terms); ret != expectedRet {
+		t.Errorf("unexpected result: %#v (expected: %#v)", ret, expectedRet)
+	}
+	if ret := FormatSolution(nil, terms); ret != "" {
+		t.Errorf("unexpected result of empty solution: %#v (expected: \"\")", ret)
+	}
+
+	// 格式错误
+	for _, data := range []string{`["om"]`, `{"x": "X"}`, `{"om": "A", "mo": "B"}`} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("unexpected result of %s: no error (expected an error)", data)
+		}
+	}
+}